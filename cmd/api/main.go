@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"serviceCatalog/config"
 	"serviceCatalog/internal/database"
 	"serviceCatalog/internal/handlers"
 	"serviceCatalog/internal/middleware"
+	"serviceCatalog/internal/retention"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,23 +27,76 @@ func main() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	// Start background purge of expired soft-deleted rows
+	retention.NewWorker(db, cfg.Retention).Start(context.Background())
+
 	// Initialize handlers
 	handler := handlers.NewHandler(db)
-	router := setupRouter(handler)
+	router := setupRouter(handler, cfg)
 
 	// Start server
 	log.Printf("Server starting on :%d \n", cfg.Server.Port)
 	router.Run(fmt.Sprintf(":%d", cfg.Server.Port))
 }
 
-func setupRouter(h *handlers.Handler) *gin.Engine {
+func setupRouter(h *handlers.Handler, cfg *config.Config) *gin.Engine {
 	r := gin.Default()
 	r.Use(middleware.Logger())
 	r.Use(gin.Recovery())
+	r.Use(middleware.Admin(cfg.Admin.Token))
 
 	r.GET("/services", h.ListServices)
+	r.POST("/services", h.CreateService)
 	r.GET("/services/:id", h.GetService)
+	r.PUT("/services/:id", h.UpdateService)
+	r.PATCH("/services/:id", h.PatchService)
 	r.GET("/services/:id/versions", h.GetServiceVersions)
+	r.POST("/services/:id/versions", h.CreateVersion)
+	r.GET("/services/:id/versions/latest", h.GetLatestVersion)
+	r.GET("/services/:id/versions/compare", h.CompareVersions)
+	r.GET("/services/:id/versions/:version", h.GetVersion)
+	r.DELETE("/services/:id/versions/:version", h.DeleteVersion)
+	r.POST("/services/:id/versions/:version/status", h.TransitionVersionStatus)
+	r.PUT("/services/:id/versions/:version/notes", h.UpdateReleaseNotes)
+	r.GET("/services/:id/versions/:version/spec", h.GetVersionSpec)
+	r.PUT("/services/:id/versions/:version/spec", h.PutVersionSpec)
+	r.GET("/services/:id/versions/:version/proto", h.GetVersionProto)
+	r.PUT("/services/:id/versions/:version/proto", h.PutVersionProto)
+	r.GET("/services/:id/versions/:version/proto/compatibility", h.CheckVersionProto)
+	r.GET("/services/:id/versions/:version/requires", h.GetVersionRequirements)
+	r.PUT("/services/:id/versions/:version/requires", h.ReplaceVersionRequirements)
+	r.GET("/services/:id/versions/:version/sbom", h.GetVersionSBOM)
+	r.PUT("/services/:id/versions/:version/sbom", h.PutVersionSBOM)
+	r.GET("/services/:id/changelog", h.GetServiceChangelog)
+	r.GET("/services/:id/dependencies", h.ListServiceDependencies)
+	r.POST("/services/:id/dependencies", h.CreateServiceDependency)
+	r.DELETE("/services/:id/dependencies/:dependencyId", h.DeleteServiceDependency)
+	r.GET("/services/:id/dependents", h.ListServiceDependents)
+	r.GET("/services/:id/impact", h.GetServiceImpact)
+	r.GET("/services/:id/graph", h.GetServiceGraph)
+	r.GET("/graph", h.GetGraph)
+	r.GET("/resolve", h.ResolveVersions)
+	r.GET("/components", h.ListComponents)
 	r.DELETE("/services/:id", h.DeleteService)
+	r.POST("/services/:id/restore", h.RestoreService)
+	r.GET("/services/:id/labels", h.GetServiceLabels)
+	r.PUT("/services/:id/labels", h.ReplaceServiceLabels)
+	r.PATCH("/services/:id/labels", h.PatchServiceLabels)
+	r.GET("/services/:id/links", h.ListServiceLinks)
+	r.POST("/services/:id/links", h.CreateServiceLink)
+	r.GET("/services/:id/links/:linkId", h.GetServiceLink)
+	r.PUT("/services/:id/links/:linkId", h.UpdateServiceLink)
+	r.DELETE("/services/:id/links/:linkId", h.DeleteServiceLink)
+	r.GET("/services/:id/lifecycle", h.GetServiceLifecycle)
+	r.POST("/services/:id/lifecycle", h.TransitionServiceLifecycle)
+	r.GET("/attributes/schema", h.GetAttributeSchema)
+	r.PUT("/attributes/schema", h.PutAttributeSchema)
+	r.DELETE("/attributes/schema", h.DeleteAttributeSchema)
+	r.GET("/teams", h.ListTeams)
+	r.POST("/teams", h.CreateTeam)
+	r.GET("/teams/:id", h.GetTeam)
+	r.PUT("/teams/:id", h.UpdateTeam)
+	r.DELETE("/teams/:id", h.DeleteTeam)
+	r.GET("/teams/:id/services", h.ListTeamServices)
 	return r
 }
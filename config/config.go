@@ -7,8 +7,10 @@ import (
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
+	Database  DatabaseConfig
+	Server    ServerConfig
+	Admin     AdminConfig
+	Retention RetentionConfig
 }
 
 type DatabaseConfig struct {
@@ -27,6 +29,15 @@ type ServerConfig struct {
 	Port int
 }
 
+type AdminConfig struct {
+	Token string // Shared secret expected in the X-Admin-Token header, admin endpoints are disabled when empty
+}
+
+type RetentionConfig struct {
+	Period   time.Duration // How long soft-deleted rows are kept before being purged, disabled when zero
+	Interval time.Duration // How often the retention worker runs
+}
+
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
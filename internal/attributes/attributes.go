@@ -0,0 +1,153 @@
+// Package attributes validates the free-form attributes of services, such
+// as {"costCenter": "42"}, against the catalog's JSON Schema and parses the
+// attribute filters used to query them.
+package attributes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// FilterPrefix starts the query parameters that filter on attributes,
+// e.g. "attr.costCenter=42".
+const FilterPrefix = "attr."
+
+// schemaURL is the URL the schema document is registered under. It is
+// never fetched.
+const schemaURL = "catalog:///attributes.json"
+
+var (
+	ErrInvalidSchema = errors.New("invalid attribute schema")
+	ErrInvalidFilter = errors.New("invalid attribute filter")
+)
+
+// Schema is a compiled JSON Schema for service attributes.
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// Compile compiles a JSON Schema document. Documents without "$schema"
+// are read as draft 2020-12. References to other documents are refused,
+// so a schema cannot make the server read files or URLs.
+func Compile(document []byte) (*Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("external reference %q is not allowed", s)
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(document)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// Violation is a single failed schema keyword. Path is the dotted path of
+// the offending attribute, e.g. "costCenter" or "pagerduty.id", and empty
+// when the attributes object itself is invalid.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+// Validate checks attributes against the schema and returns one violation
+// per failed keyword, ordered by path.
+func (s *Schema) Validate(attributes map[string]interface{}) []Violation {
+	// The validator only accepts JSON types, so an absent object is empty
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	err := s.schema.Validate(attributes)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []Violation{{Message: err.Error()}}
+	}
+
+	var violations []Violation
+	var collect func(*jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			violations = append(violations, Violation{Path: dottedPath(e.InstanceLocation), Message: e.Message})
+			return
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(validationErr)
+
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return violations
+}
+
+// dottedPath converts a JSON Pointer such as "/pagerduty/id" into
+// "pagerduty.id", decoding the ~1 and ~0 escapes.
+func dottedPath(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// Filter matches services whose attribute at Path equals one of Values.
+// Values are compared with the text form of the attribute, so "42"
+// matches both the number 42 and the string "42".
+type Filter struct {
+	Path   []string
+	Values []string
+}
+
+// ParseFilters reads the attribute filters from query parameters named
+// "attr.<path>", where path is a dotted path such as "pagerduty.id".
+// Repeating a parameter matches any of its values. Filters are ordered
+// by parameter name.
+func ParseFilters(query url.Values) ([]Filter, error) {
+	var names []string
+	for name := range query {
+		if strings.HasPrefix(name, FilterPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	filters := make([]Filter, 0, len(names))
+	for _, name := range names {
+		path := strings.Split(strings.TrimPrefix(name, FilterPrefix), ".")
+		for _, segment := range path {
+			if segment == "" {
+				return nil, fmt.Errorf("%w %q: path segments must not be empty", ErrInvalidFilter, name)
+			}
+		}
+		filters = append(filters, Filter{Path: path, Values: query[name]})
+	}
+	return filters, nil
+}
+
+// PathLiteral returns the path as a PostgreSQL text[] literal such as
+// {"pagerduty","id"}, suitable as the right operand of the #>> operator.
+func (f Filter) PathLiteral() string {
+	quoted := make([]string, len(f.Path))
+	for i, segment := range f.Path {
+		segment = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(segment)
+		quoted[i] = `"` + segment + `"`
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
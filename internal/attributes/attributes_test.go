@@ -0,0 +1,93 @@
+package attributes
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+	"type": "object",
+	"properties": {
+		"costCenter": {"type": "string", "pattern": "^[0-9]+$"},
+		"classification": {"enum": ["public", "internal", "confidential"]},
+		"pagerduty": {
+			"type": "object",
+			"properties": {"id": {"type": "string"}},
+			"required": ["id"]
+		}
+	},
+	"additionalProperties": false
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, schema.Validate(nil))
+	assert.Empty(t, schema.Validate(map[string]interface{}{
+		"costCenter": "42",
+		"pagerduty":  map[string]interface{}{"id": "P123"},
+	}))
+
+	violations := schema.Validate(map[string]interface{}{
+		"costCenter":     "forty-two",
+		"classification": "secret",
+		"pagerduty":      map[string]interface{}{},
+	})
+	paths := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		paths = append(paths, violation.Path)
+		assert.NotEmpty(t, violation.Message)
+	}
+	assert.Equal(t, []string{"classification", "costCenter", "pagerduty"}, paths)
+
+	violations = schema.Validate(map[string]interface{}{"owner": "me"})
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "", violations[0].Path)
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	for _, document := range []string{
+		`not json`,
+		`{"type": "no-such-type"}`,
+		`{"$ref": "file:///etc/passwd"}`,
+		`{"$ref": "https://example.com/schema.json"}`,
+	} {
+		_, err := Compile([]byte(document))
+		assert.True(t, errors.Is(err, ErrInvalidSchema), document)
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	query := url.Values{
+		"attr.costCenter":   {"42"},
+		"attr.pagerduty.id": {"P1", "P2"},
+		"search":            {"auth"},
+	}
+
+	filters, err := ParseFilters(query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Filter{
+		{Path: []string{"costCenter"}, Values: []string{"42"}},
+		{Path: []string{"pagerduty", "id"}, Values: []string{"P1", "P2"}},
+	}, filters)
+	assert.Equal(t, `{"pagerduty","id"}`, filters[1].PathLiteral())
+
+	for _, name := range []string{"attr.", "attr.a..b", "attr.a."} {
+		_, err = ParseFilters(url.Values{name: {"x"}})
+		assert.True(t, errors.Is(err, ErrInvalidFilter), name)
+	}
+}
+
+func TestPathLiteralEscapes(t *testing.T) {
+	filter := Filter{Path: []string{`a"b`, `c\d`, "e,f"}}
+
+	assert.Equal(t, `{"a\"b","c\\d","e,f"}`, filter.PathLiteral())
+}
@@ -0,0 +1,109 @@
+// Package changelog renders the release notes of a service's versions as
+// a single Markdown or HTML changelog.
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+)
+
+// Entry is the release of one version in a changelog.
+type Entry struct {
+	Version string    `json:"version"`
+	Status  string    `json:"status"`
+	Date    time.Time `json:"date"`
+	Notes   string    `json:"release_notes"`
+}
+
+// noNotes stands in for the notes of a version published without any.
+const noNotes = "_No release notes._"
+
+// Markdown renders the entries, in the order given, below a level one
+// heading with the title. Each version gets a level two heading followed
+// by its notes.
+func Markdown(title string, entries []Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "\n## %s\n\n", heading(entry))
+		notes := strings.TrimSpace(entry.Notes)
+		if notes == "" {
+			notes = noNotes
+		}
+		b.WriteString(notes)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// HTML renders the entries as a standalone HTML page. Raw HTML and unsafe
+// links in the notes are dropped, so the page can be linked from other
+// sites.
+func HTML(title string, entries []Entry) (string, error) {
+	sections := make([]section, 0, len(entries))
+	for _, entry := range entries {
+		notes := strings.TrimSpace(entry.Notes)
+		if notes == "" {
+			notes = noNotes
+		}
+		var body bytes.Buffer
+		if err := goldmark.Convert([]byte(notes), &body); err != nil {
+			return "", err
+		}
+		sections = append(sections, section{
+			ID:      "v" + entry.Version,
+			Heading: heading(entry),
+			Body:    template.HTML(body.String()),
+		})
+	}
+
+	var page bytes.Buffer
+	err := pageTemplate.Execute(&page, struct {
+		Title    string
+		Sections []section
+	}{title, sections})
+	if err != nil {
+		return "", err
+	}
+	return page.String(), nil
+}
+
+// heading is the title of an entry, e.g. "2.1.0 (2024-01-20)", with the
+// status appended unless the version is released.
+func heading(entry Entry) string {
+	text := fmt.Sprintf("%s (%s)", entry.Version, entry.Date.UTC().Format("2006-01-02"))
+	if entry.Status != "" && entry.Status != "released" {
+		text += " [" + entry.Status + "]"
+	}
+	return text
+}
+
+// section is an entry prepared for pageTemplate; Body is trusted HTML
+// rendered from the notes.
+type section struct {
+	ID      string
+	Heading string
+	Body    template.HTML
+}
+
+var pageTemplate = template.Must(template.New("changelog").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- range .Sections}}
+<section id="{{.ID}}">
+<h2>{{.Heading}}</h2>
+{{.Body}}</section>
+{{- end}}
+</body>
+</html>
+`))
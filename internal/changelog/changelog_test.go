@@ -0,0 +1,45 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var entries = []Entry{
+	{Version: "2.0.0", Status: "released", Date: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), Notes: "### Breaking\n\n- Drop v1 API\n"},
+	{Version: "1.1.0", Status: "deprecated", Date: time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)},
+}
+
+func TestMarkdown(t *testing.T) {
+	expected := "# Payments changelog\n\n" +
+		"## 2.0.0 (2024-03-01)\n\n### Breaking\n\n- Drop v1 API\n\n" +
+		"## 1.1.0 (2024-01-20) [deprecated]\n\n_No release notes._\n"
+
+	assert.Equal(t, expected, Markdown("Payments changelog", entries))
+}
+
+func TestHTML(t *testing.T) {
+	page, err := HTML("Payments <changelog>", entries)
+
+	assert.NoError(t, err)
+	assert.Contains(t, page, "<title>Payments &lt;changelog&gt;</title>")
+	assert.Contains(t, page, `<section id="v2.0.0">`)
+	assert.Contains(t, page, "<h3>Breaking</h3>")
+	assert.Contains(t, page, "<li>Drop v1 API</li>")
+	assert.Contains(t, page, "<em>No release notes.</em>")
+	assert.Less(t, strings.Index(page, "2.0.0"), strings.Index(page, "1.1.0"))
+}
+
+func TestHTMLDropsRawHTMLAndUnsafeLinks(t *testing.T) {
+	page, err := HTML("Payments", []Entry{{
+		Version: "1.0.0",
+		Notes:   "<script>alert(1)</script>\n\n[docs](javascript:alert(1))",
+	}})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, page, "<script>")
+	assert.NotContains(t, page, "javascript:")
+}
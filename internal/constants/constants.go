@@ -12,14 +12,103 @@ const (
 	MaxPageSize     = 100
 	DefaultPage     = 1
 
+	// Service field limits
+	MaxServiceNameLength        = 255
+	MaxServiceDescriptionLength = 2000
+
+	// Team field limits
+	MaxTeamNameLength    = 255
+	MaxContactLength     = 255
+	MaxChatChannelLength = 255
+
+	// Link field limits
+	MaxLinkURLLength   = 2048
+	MaxLinkTitleLength = 255
+
+	// Lifecycle field limits
+	MaxActorLength  = 255
+	MaxReasonLength = 2000
+
+	// Version field limits
+	MaxReleaseNotesLength = 20000
+	MaxSpecSize           = 5 << 20
+	MaxDescriptorSetSize  = 5 << 20
+	MaxSBOMSize           = 20 << 20
+	MaxRequirements       = 100
+	MaxRangeLength        = 255
+
+	// Resolver limits
+	MaxResolveServices = 50
+	MaxResolveSteps    = 10000
+
+	// Specification comparison limits
+	MaxCompareSteps = 100000
+
 	// Sort settings
 	DefaultSortField = "id"
 	DefaultSortOrder = "asc"
 	DescSortOrder    = "desc"
 
-	True        = "true"
-	ShowDeleted = "showDeleted"
-	Name        = "name"
-	Description = "description"
-	Error       = "error"
+	// Patch content types
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+
+	// Changelog content types
+	ContentTypeMarkdown = "text/markdown"
+
+	// Specification content types
+	ContentTypeYAML        = "application/yaml"
+	ContentTypeProtobuf    = "application/x-protobuf"
+	ContentTypeOctetStream = "application/octet-stream"
+
+	// Admin settings
+	AdminTokenHeader = "X-Admin-Token"
+	IsAdmin          = "isAdmin"
+
+	// Query parameters
+	Purge             = "purge"
+	ExcludePrerelease = "excludePrerelease"
+	Range             = "range"
+	Owner             = "owner"
+	Selector          = "selector"
+	MissingLink       = "missingLink"
+	Force             = "force"
+	ReleasedOnly      = "releasedOnly"
+	Format            = "format"
+	From              = "from"
+	To                = "to"
+	RejectBreaking    = "rejectBreaking"
+	Against           = "against"
+	Services          = "services"
+	PURL              = "purl"
+
+	True            = "true"
+	ShowDeleted     = "showDeleted"
+	Name            = "name"
+	Slug            = "slug"
+	Description     = "description"
+	Error           = "error"
+	Number          = "number"
+	CreatedAt       = "created_at"
+	OwnerID         = "owner_id"
+	ContactEmail    = "contact_email"
+	ChatChannel     = "chat_channel"
+	Labels          = "labels"
+	Attributes      = "attributes"
+	Type            = "type"
+	URL             = "url"
+	Title           = "title"
+	Lifecycle       = "lifecycle"
+	Stage           = "stage"
+	Actor           = "actor"
+	Reason          = "reason"
+	Status          = "status"
+	DeprecationDate = "deprecation_date"
+	EndOfLifeDate   = "end_of_life_date"
+	ReleaseNotes    = "release_notes"
+	DependsOnID     = "depends_on_id"
+	Kind            = "kind"
+	Criticality     = "criticality"
+	Spec            = "spec"
+	Requires        = "requires"
 )
@@ -10,6 +10,8 @@ const (
 	StatusNotAcceptable       = 406
 	StatusRequestTimeout      = 408
 	StatusConflict            = 409
+	StatusPayloadTooLarge     = 413
+	StatusUnsupportedMedia    = 415
 	StatusUnprocessableEntity = 422
 	StatusInternalServerError = 500
 )
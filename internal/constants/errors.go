@@ -7,9 +7,37 @@ const (
 	ErrRecordNotFound = "record not found"
 
 	// Validation errors
-	ErrInvalidServiceID = "invalid service ID: must be a positive integer"
-	ErrRequiredField    = "required field missing: %s"
-	ErrInvalidFormat    = "invalid format for field: %s"
+	ErrInvalidServiceID  = "invalid service ID: must be a positive integer or a service slug"
+	ErrInvalidRange      = "invalid version range"
+	ErrInvalidVersionRef = "invalid version: must be a positive integer ID or a semantic version number"
+	ErrRequiredField     = "required field missing: %s"
+	ErrInvalidFormat     = "invalid format for field: %s"
+	ErrValidationFailed  = "validation failed"
+	ErrInvalidBody       = "invalid request body"
+	ErrFieldTooLong      = "must be at most %d characters"
+	ErrFieldRequired     = "is required"
+	ErrFieldReadOnly     = "is read-only"
+	ErrFieldUnknown      = "is not a known field"
+	ErrFieldNotOneOf     = "must be one of %s"
+	ErrInvalidSlug       = "must contain lowercase letters, digits and single hyphens, include a letter and be at most 100 characters"
+	ErrInvalidTeamID     = "invalid team ID: must be a positive integer"
+	ErrInvalidEmail      = "must be a valid email address"
+	ErrUnknownTeam       = "does not reference an existing team"
+	ErrInvalidSelector   = "invalid label selector"
+	ErrInvalidAttrFilter = "invalid attribute filter"
+	ErrInvalidLinkID     = "invalid link ID: must be a positive integer"
+	ErrInvalidURL        = "must be an absolute http or https URL"
+	ErrEndOfLifeTooEarly = "must not be before the deprecation date"
+	ErrDateNotApplicable = "does not apply to this status"
+	ErrInvalidDepID      = "invalid dependency ID: must be a positive integer"
+	ErrUnknownService    = "does not reference an existing service"
+	ErrSelfDependency    = "must not be the service itself"
+
+	// Patch errors
+	ErrUnsupportedPatchType = "unsupported patch content type"
+	ErrInvalidPatch         = "invalid patch document"
+	ErrPatchFailed          = "failed to apply patch"
+	ErrPatchTestFailed      = "patch test operation failed"
 
 	// HTTP errors
 	ErrInternalServer = "internal server error"
@@ -19,17 +47,109 @@ const (
 	ErrNotFound       = "resource not found"
 
 	// Service specific errors
-	ErrServiceNotFound     = "service not found"
-	ErrServiceFetchFailed  = "Failed to fetch service"
-	ErrVersionFetchFailed  = "Failed to fetch versions"
-	ErrServiceCountFailed  = "Failed to count services"
-	ErrServicesFetchFailed = "Failed to fetch services"
-	ErrServiceDeleteFailed = "failed to delete service"
-	ErrVersionNotFound     = "version not found"
+	ErrServiceNotFound         = "service not found"
+	ErrServiceFetchFailed      = "Failed to fetch service"
+	ErrVersionFetchFailed      = "Failed to fetch versions"
+	ErrServiceCountFailed      = "Failed to count services"
+	ErrServicesFetchFailed     = "Failed to fetch services"
+	ErrServiceDeleteFailed     = "failed to delete service"
+	ErrServiceCreateFailed     = "failed to create service"
+	ErrServiceUpdateFailed     = "failed to update service"
+	ErrVersionNotFound         = "version not found"
+	ErrVersionExists           = "version already exists for this service"
+	ErrVersionCreateFailed     = "failed to create version"
+	ErrVersionDeleteFailed     = "failed to delete version"
+	ErrVersionUpdateFailed     = "failed to change version status"
+	ErrIllegalVersionState     = "illegal version status transition"
+	ErrNotesUpdateFailed       = "failed to update release notes"
+	ErrChangelogFailed         = "failed to render changelog"
+	ErrSpecNotFound            = "version has no specification"
+	ErrSpecFetchFailed         = "failed to fetch specification"
+	ErrSpecUpdateFailed        = "failed to store specification"
+	ErrSpecTooLarge            = "specification is too large"
+	ErrInvalidSpec             = "invalid OpenAPI document"
+	ErrSpecCompareFailed       = "failed to compare specifications"
+	ErrSpecTooComplex          = "too many schemas to compare"
+	ErrBreakingChange          = "specification has breaking changes"
+	ErrDescriptorsNotFound     = "version has no descriptor set"
+	ErrDescriptorsFetchFailed  = "failed to fetch descriptor set"
+	ErrDescriptorsUpdateFailed = "failed to store descriptor set"
+	ErrDescriptorsTooLarge     = "descriptor set is too large"
+	ErrInvalidDescriptors      = "invalid FileDescriptorSet"
+	ErrCompatibilityFailed     = "failed to check compatibility"
+	ErrSBOMNotFound            = "version has no SBOM"
+	ErrSBOMFetchFailed         = "failed to fetch SBOM"
+	ErrSBOMUpdateFailed        = "failed to store SBOM"
+	ErrSBOMTooLarge            = "SBOM is too large"
+	ErrInvalidSBOM             = "invalid SBOM"
+	ErrComponentsFetchFailed   = "failed to fetch components"
+	ErrServiceDeleted          = "service is deleted"
+	ErrSlugExists              = "slug is already in use"
+	ErrServiceNotDeleted       = "service is not deleted"
+	ErrServiceRestoreFailed    = "failed to restore service"
+	ErrServicePurgeFailed      = "failed to purge service"
+	ErrLabelUpdateFailed       = "failed to update labels"
+	ErrLabelsFetchFailed       = "failed to fetch labels"
+
+	// Attribute schema errors
+	ErrAttrSchemaNotFound     = "attribute schema not defined"
+	ErrAttrSchemaFetchFailed  = "failed to fetch attribute schema"
+	ErrAttrSchemaUpdateFailed = "failed to update attribute schema"
+	ErrAttrSchemaDeleteFailed = "failed to delete attribute schema"
+	ErrInvalidAttrSchema      = "invalid attribute schema"
+
+	// Link specific errors
+	ErrLinkNotFound     = "link not found"
+	ErrLinkExists       = "link already exists for this service"
+	ErrLinksFetchFailed = "failed to fetch links"
+	ErrLinkCreateFailed = "failed to create link"
+	ErrLinkUpdateFailed = "failed to update link"
+	ErrLinkDeleteFailed = "failed to delete link"
+
+	// Dependency specific errors
+	ErrDependencyNotFound      = "dependency not found"
+	ErrDependencyExists        = "dependency already exists"
+	ErrDependenciesFetchFailed = "failed to fetch dependencies"
+	ErrDependencyCreateFailed  = "failed to create dependency"
+	ErrDependencyDeleteFailed  = "failed to delete dependency"
+	ErrServiceHasDependents    = "service has dependents"
+	ErrImpactFailed            = "failed to analyze impact"
+	ErrGraphRenderFailed       = "failed to render graph"
+
+	// Requirement specific errors
+	ErrRequirementsFetchFailed  = "failed to fetch requirements"
+	ErrRequirementsUpdateFailed = "failed to update requirements"
+	ErrVersionRequired          = "version is still required"
+	ErrResolveFailed            = "failed to resolve versions"
+	ErrResolveTooComplex        = "too many version combinations to resolve"
+
+	// Lifecycle specific errors
+	ErrIllegalTransition     = "illegal lifecycle transition"
+	ErrServiceNotRetired     = "only retired services can be deleted"
+	ErrLifecycleFetchFailed  = "failed to fetch lifecycle history"
+	ErrLifecycleUpdateFailed = "failed to change lifecycle stage"
+
+	// Team specific errors
+	ErrTeamNotFound     = "team not found"
+	ErrTeamFetchFailed  = "failed to fetch team"
+	ErrTeamsFetchFailed = "failed to fetch teams"
+	ErrTeamCreateFailed = "failed to create team"
+	ErrTeamUpdateFailed = "failed to update team"
+	ErrTeamDeleteFailed = "failed to delete team"
+	ErrTeamExists       = "team name is already in use"
+	ErrTeamOwnsServices = "team still owns services"
+	ErrOwnerFetchFailed = "failed to fetch service owners"
 )
 
 type ServiceError struct {
-	Status  int    `json:"status"`
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Details string       `json:"details,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a validation failure for a single request field.
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
 }
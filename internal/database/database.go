@@ -1,6 +1,7 @@
 package database
 
 import (
+	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"serviceCatalog/config"
@@ -8,7 +9,8 @@ import (
 )
 
 func InitDB(cfg *config.Config) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{})
+	// TranslateError maps unique constraint violations to gorm.ErrDuplicatedKey
+	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -24,10 +26,40 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)       // Maximum number of open connections
 	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime) // Maximum lifetime of a connection
 
-	err = db.AutoMigrate(&models.Service{}, &models.Version{})
+	err = db.AutoMigrate(&models.Team{}, &models.Service{}, &models.Version{}, &models.ServiceLabel{}, &models.AttributeSchema{}, &models.ServiceLink{}, &models.LifecycleTransition{}, &models.ServiceDependency{}, &models.VersionSpec{}, &models.VersionDescriptorSet{}, &models.VersionRequirement{}, &models.VersionSBOM{}, &models.SBOMComponent{})
 	if err != nil {
 		return nil, err
 	}
 
+	report, err := MigrateVersionComponents(db)
+	if err != nil {
+		return nil, err
+	}
+	logVersionMigration(report)
+
+	if err = BackfillServiceSlugs(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// logVersionMigration logs a summary of a version component migration
+// and a warning for every version whose number could not be parsed. Such
+// versions keep 0.0.0 components, so they sort and filter as 0.0.0 and
+// are skipped by the resolver until they are fixed or deleted.
+func logVersionMigration(report *VersionMigrationReport) {
+	for _, invalid := range report.Invalid {
+		logrus.WithFields(logrus.Fields{
+			"version_id": invalid.ID,
+			"service_id": invalid.ServiceID,
+			"number":     invalid.Number,
+			"reason":     invalid.Reason,
+		}).Warn("Stored version number is not a valid semantic version")
+	}
+	logrus.WithFields(logrus.Fields{
+		"scanned": report.Scanned,
+		"updated": report.Updated,
+		"invalid": len(report.Invalid),
+	}).Info("Version component migration finished")
+}
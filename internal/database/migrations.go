@@ -0,0 +1,115 @@
+package database
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/semver"
+	"serviceCatalog/internal/slug"
+	"strings"
+)
+
+// InvalidVersion is a stored version whose number is not a valid
+// semantic version.
+type InvalidVersion struct {
+	ID        uint   `json:"id"`
+	ServiceID uint   `json:"service_id"`
+	Number    string `json:"number"`
+	Reason    string `json:"reason"`
+}
+
+// VersionMigrationReport summarizes a run of MigrateVersionComponents.
+type VersionMigrationReport struct {
+	Scanned int              `json:"scanned"`
+	Updated int              `json:"updated"`
+	Invalid []InvalidVersion `json:"invalid"`
+}
+
+// MigrateVersionComponents backfills the semantic version columns of
+// versions created before they existed. Rows still at 0.0.0 are re-parsed
+// on every start, so versions with invalid numbers keep being reported
+// until they are fixed or deleted instead of being silently dropped; see
+// logVersionMigration.
+func MigrateVersionComponents(db *gorm.DB) (*VersionMigrationReport, error) {
+	var versions []models.Version
+	err := db.Unscoped().
+		Where("major = 0 AND minor = 0 AND patch = 0 AND pre_release = ''").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VersionMigrationReport{Scanned: len(versions), Invalid: []InvalidVersion{}}
+	for _, version := range versions {
+		parsed, err := semver.Parse(version.Number)
+		if err != nil {
+			report.Invalid = append(report.Invalid, InvalidVersion{
+				ID:        version.ID,
+				ServiceID: version.ServiceID,
+				Number:    version.Number,
+				Reason:    err.Error(),
+			})
+			continue
+		}
+
+		version.SetComponents(parsed)
+		err = db.Unscoped().Model(&version).UpdateColumns(map[string]interface{}{
+			"major":           version.Major,
+			"minor":           version.Minor,
+			"patch":           version.Patch,
+			"pre_release":     version.PreRelease,
+			"pre_release_key": version.PreReleaseKey,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+		report.Updated++
+	}
+
+	return report, nil
+}
+
+// BackfillServiceSlugs generates slugs for services created before the
+// slug column existed. When the generated slug is already taken the
+// service ID is appended, e.g. "payment-gateway-7", and when that is taken
+// too a counter, e.g. "payment-gateway-7-2", until the slug is free.
+func BackfillServiceSlugs(db *gorm.DB) error {
+	var services []models.Service
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Find(&services).Error; err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		base := slug.Make(service.Name)
+		candidate := base
+		for attempt := 1; ; attempt++ {
+			var taken int64
+			if err := db.Unscoped().Model(&models.Service{}).Where("slug = ?", candidate).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken == 0 {
+				break
+			}
+			suffix := fmt.Sprintf("-%d", service.ID)
+			if attempt > 1 {
+				suffix = fmt.Sprintf("-%d-%d", service.ID, attempt)
+			}
+			candidate = base
+			if len(candidate)+len(suffix) > slug.MaxLength {
+				candidate = strings.TrimRight(candidate[:slug.MaxLength-len(suffix)], "-")
+			}
+			candidate += suffix
+		}
+
+		err := db.Unscoped().Model(&service).UpdateColumn("slug", candidate).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(services) > 0 {
+		logrus.WithField("services", len(services)).Info("Backfilled service slugs")
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Package graph renders the service dependency graph as Graphviz DOT,
+// Mermaid flowcharts and GraphML.
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format of the graph.
+type Format string
+
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+	FormatGraphML Format = "graphml"
+)
+
+// Formats lists the supported formats; the first one is the default.
+var Formats = []Format{FormatDOT, FormatMermaid, FormatGraphML}
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatMermaid:
+		return "text/vnd.mermaid"
+	case FormatGraphML:
+		return "application/graphml+xml"
+	default:
+		return "text/vnd.graphviz"
+	}
+}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, bool) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// FormatForContentType returns the format with the given media type.
+func FormatForContentType(contentType string) (Format, bool) {
+	for _, format := range Formats {
+		if format.ContentType() == contentType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// Node is a service in the graph.
+type Node struct {
+	ID       uint
+	Name     string
+	Slug     string
+	Versions int
+	Deleted  bool
+}
+
+// Edge points from a service to a service it depends on.
+type Edge struct {
+	From        uint
+	To          uint
+	Kind        string
+	Criticality string
+}
+
+// Graph is a set of services and the dependencies between them.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Render writes the graph in the given format.
+func (g Graph) Render(format Format) (string, error) {
+	switch format {
+	case FormatMermaid:
+		return g.Mermaid(), nil
+	case FormatGraphML:
+		return g.GraphML()
+	default:
+		return g.DOT(), nil
+	}
+}
+
+// DOT renders the graph as a Graphviz digraph. Soft-deleted services are
+// drawn dashed.
+func (g Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph catalog {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "\t%s [label=%s", nodeID(node.ID), dotQuote(node.Name+"\n"+versionsLabel(node.Versions)))
+		if node.Deleted {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", nodeID(edge.From), nodeID(edge.To), dotQuote(edgeLabel(edge)))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a left-to-right Mermaid flowchart.
+// Soft-deleted services are drawn with rounded corners.
+func (g Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		open, close := "[", "]"
+		if node.Deleted {
+			open, close = "(", ")"
+		}
+		fmt.Fprintf(&b, "    %s%s\"%s<br/>%s\"%s\n", nodeID(node.ID), open, mermaidEscape(node.Name), versionsLabel(node.Versions), close)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "    %s -->|%s| %s\n", nodeID(edge.From), mermaidEscape(edgeLabel(edge)), nodeID(edge.To))
+	}
+	return b.String()
+}
+
+// GraphML renders the graph as a GraphML document with the name, slug,
+// version count and deletion of services and the kind and criticality of
+// dependencies as data attributes.
+func (g Graph) GraphML() (string, error) {
+	document := graphML{
+		Namespace: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", Name: "name", Type: "string"},
+			{ID: "slug", For: "node", Name: "slug", Type: "string"},
+			{ID: "versions", For: "node", Name: "versions", Type: "int"},
+			{ID: "deleted", For: "node", Name: "deleted", Type: "boolean"},
+			{ID: "kind", For: "edge", Name: "kind", Type: "string"},
+			{ID: "criticality", For: "edge", Name: "criticality", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "catalog", EdgeDefault: "directed"},
+	}
+	for _, node := range g.Nodes {
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLElement{
+			ID: nodeID(node.ID),
+			Data: []graphMLData{
+				{Key: "name", Value: node.Name},
+				{Key: "slug", Value: node.Slug},
+				{Key: "versions", Value: strconv.Itoa(node.Versions)},
+				{Key: "deleted", Value: strconv.FormatBool(node.Deleted)},
+			},
+		})
+	}
+	for i, edge := range g.Edges {
+		data := []graphMLData{{Key: "kind", Value: edge.Kind}}
+		if edge.Criticality != "" {
+			data = append(data, graphMLData{Key: "criticality", Value: edge.Criticality})
+		}
+		document.Graph.Edges = append(document.Graph.Edges, graphMLElement{
+			ID:     "e" + strconv.Itoa(i+1),
+			Source: nodeID(edge.From),
+			Target: nodeID(edge.To),
+			Data:   data,
+		})
+	}
+
+	output, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(output) + "\n", nil
+}
+
+type graphML struct {
+	XMLName   xml.Name     `xml:"graphml"`
+	Namespace string       `xml:"xmlns,attr"`
+	Keys      []graphMLKey `xml:"key"`
+	Graph     graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string           `xml:"id,attr"`
+	EdgeDefault string           `xml:"edgedefault,attr"`
+	Nodes       []graphMLElement `xml:"node"`
+	Edges       []graphMLElement `xml:"edge"`
+}
+
+// graphMLElement is a node, or an edge when Source and Target are set.
+type graphMLElement struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr,omitempty"`
+	Target string        `xml:"target,attr,omitempty"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// nodeID is the identifier of a service in every format, e.g. "s12".
+func nodeID(id uint) string {
+	return "s" + strconv.FormatUint(uint64(id), 10)
+}
+
+func versionsLabel(versions int) string {
+	if versions == 1 {
+		return "1 version"
+	}
+	return strconv.Itoa(versions) + " versions"
+}
+
+func edgeLabel(edge Edge) string {
+	if edge.Criticality == "" {
+		return edge.Kind
+	}
+	return edge.Kind + ", " + edge.Criticality
+}
+
+// dotQuote quotes s as a DOT string, escaping quotes and backslashes and
+// turning newlines into centered line breaks.
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// mermaidEscape replaces the characters that end a Mermaid label with
+// entity codes.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
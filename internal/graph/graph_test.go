@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var catalog = Graph{
+	Nodes: []Node{
+		{ID: 1, Name: "Authentication Service", Slug: "authentication-service", Versions: 3},
+		{ID: 2, Name: `Payment "Gateway"`, Slug: "payment-gateway", Versions: 1, Deleted: true},
+	},
+	Edges: []Edge{{From: 2, To: 1, Kind: "sync-http", Criticality: "high"}},
+}
+
+func TestDOT(t *testing.T) {
+	expected := "digraph catalog {\n\trankdir=LR;\n\tnode [shape=box];\n" +
+		"\ts1 [label=\"Authentication Service\\n3 versions\"];\n" +
+		"\ts2 [label=\"Payment \\\"Gateway\\\"\\n1 version\", style=dashed];\n" +
+		"\ts2 -> s1 [label=\"sync-http, high\"];\n}\n"
+
+	assert.Equal(t, expected, catalog.DOT())
+}
+
+func TestMermaid(t *testing.T) {
+	expected := "flowchart LR\n" +
+		"    s1[\"Authentication Service<br/>3 versions\"]\n" +
+		"    s2(\"Payment #quot;Gateway#quot;<br/>1 version\")\n" +
+		"    s2 -->|sync-http, high| s1\n"
+
+	assert.Equal(t, expected, catalog.Mermaid())
+}
+
+func TestGraphML(t *testing.T) {
+	document, err := catalog.GraphML()
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(document, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, document, `<graph id="catalog" edgedefault="directed">`)
+	assert.Contains(t, document, `<data key="name">Payment &#34;Gateway&#34;</data>`)
+	assert.Contains(t, document, `<data key="versions">3</data>`)
+	assert.Contains(t, document, `<edge id="e1" source="s2" target="s1">`)
+	assert.Contains(t, document, `<data key="criticality">high</data>`)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, ok := ParseFormat("mermaid")
+	assert.True(t, ok)
+	assert.Equal(t, FormatMermaid, format)
+
+	_, ok = ParseFormat("svg")
+	assert.False(t, ok)
+
+	format, ok = FormatForContentType("application/graphml+xml")
+	assert.True(t, ok)
+	assert.Equal(t, FormatGraphML, format)
+}
@@ -0,0 +1,212 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+	"io"
+	"net/http"
+	"serviceCatalog/internal/attributes"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/middleware"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+)
+
+// GetAttributeSchema handles GET /attributes/schema endpoint.
+//
+// Returns the JSON Schema that the attributes of every service must satisfy.
+//
+// Returns:
+//
+//	200: AttributeSchemaResponse
+//	404: No schema has been defined
+//	500: Database error
+//
+// Example:
+//
+//	GET /attributes/schema
+func (h *Handler) GetAttributeSchema(c *gin.Context) {
+	var schema models.AttributeSchema
+	result := h.db.Limit(1).Find(&schema, models.AttributeSchemaID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrAttrSchemaFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrAttrSchemaNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, AttributeSchemaResponse{Schema: []byte(schema.Document), UpdatedAt: schema.UpdatedAt})
+}
+
+// PutAttributeSchema handles PUT /attributes/schema endpoint.
+//
+// Replaces the JSON Schema for service attributes. Documents without
+// "$schema" are read as draft 2020-12 and may not reference other
+// documents. Existing services are not revalidated; they must satisfy the
+// new schema on their next write. Requires a valid X-Admin-Token header.
+//
+// Request Body: JSON Schema document
+//
+// Returns:
+//
+//	200: AttributeSchemaResponse of the stored schema
+//	400: Unreadable body
+//	403: Missing admin credentials
+//	422: The document is not a valid JSON Schema
+//	500: Database error
+//
+// Example:
+//
+//	PUT /attributes/schema
+//	{"type": "object", "properties": {"costCenter": {"type": "string"}}}
+func (h *Handler) PutAttributeSchema(c *gin.Context) {
+	if !requireAdmin(c, "changing the attribute schema requires admin credentials") {
+		return
+	}
+
+	document, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if _, err := attributes.Compile(document); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrInvalidAttrSchema,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	schema := models.AttributeSchema{ID: models.AttributeSchemaID, Document: string(document)}
+	result := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"document", "updated_at"}),
+	}).Create(&schema)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrAttrSchemaUpdateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, AttributeSchemaResponse{Schema: document, UpdatedAt: schema.UpdatedAt})
+}
+
+// DeleteAttributeSchema handles DELETE /attributes/schema endpoint.
+//
+// Removes the attribute schema, after which any attributes object is
+// accepted. Requires a valid X-Admin-Token header.
+//
+// Returns:
+//
+//	204: Schema removed, or none was defined
+//	403: Missing admin credentials
+//	500: Database error
+func (h *Handler) DeleteAttributeSchema(c *gin.Context) {
+	if !requireAdmin(c, "changing the attribute schema requires admin credentials") {
+		return
+	}
+
+	if err := h.db.Delete(&models.AttributeSchema{}, models.AttributeSchemaID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrAttrSchemaDeleteFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// validateAttributes checks service attributes against the stored schema,
+// writing a 422 response with one field error per violation, named
+// "attributes.<path>", when they do not conform. Any attributes object is
+// accepted while no schema is defined.
+func (h *Handler) validateAttributes(c *gin.Context, values models.Attributes) bool {
+	var stored models.AttributeSchema
+	result := h.db.Limit(1).Find(&stored, models.AttributeSchemaID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrAttrSchemaFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return false
+	}
+	if result.RowsAffected == 0 {
+		return true
+	}
+
+	schema, err := attributes.Compile([]byte(stored.Document))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrInvalidAttrSchema,
+			Details: err.Error(),
+		})
+		return false
+	}
+
+	var fieldErrors []*constants.FieldError
+	for _, violation := range schema.Validate(values) {
+		field := constants.Attributes
+		if violation.Path != "" {
+			field += "." + violation.Path
+		}
+		fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: violation.Message})
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return false
+	}
+	return true
+}
+
+// requireAdmin reports whether the request carries admin credentials,
+// writing a 403 response with details otherwise.
+func requireAdmin(c *gin.Context, details string) bool {
+	if middleware.IsAdmin(c) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, &constants.ServiceError{
+		Status:  constants.StatusForbidden,
+		Message: constants.ErrForbidden,
+		Details: details,
+	})
+	return false
+}
+
+// attributeFilterCondition compiles attribute filters into a parameterized
+// SQL condition on the services table using the JSONB #>> operator. Paths
+// and values are only ever passed as arguments.
+func attributeFilterCondition(filters []attributes.Filter) (string, []interface{}) {
+	condition := ""
+	var args []interface{}
+	for i, filter := range filters {
+		if i > 0 {
+			condition += " AND "
+		}
+		condition += "services.attributes #>> ?::text[] IN ?"
+		args = append(args, filter.PathLiteral(), filter.Values)
+	}
+	return "(" + condition + ")", args
+}
@@ -0,0 +1,142 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/sbom"
+	"serviceCatalog/internal/validation"
+)
+
+// ListComponents handles GET /components endpoint.
+//
+// Lists the service versions whose SBOM contains a package, to answer
+// questions like "which services ship log4j-core 2.14.1?". Without a
+// version in the package URL every version of the package matches; with
+// one only components of that version do. Qualifiers and subpath are
+// ignored. Soft-deleted services and versions are skipped. Results are
+// ordered by service slug, then by version, newest first.
+//
+// Query Parameters:
+//   - purl (string): Package URL, required
+//   - releasedOnly (bool): Only include released versions
+//   - page (int): Page number, default 1
+//   - pageSize (int): Items per page, default 10, max 100
+//
+// Returns:
+//
+//	200: ListComponentsResponse
+//	400: Invalid query parameters
+//	422: Missing or invalid package URL
+//	500: Database error
+//
+// Example:
+//
+//	GET /components?purl=pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1
+func (h *Handler) ListComponents(c *gin.Context) {
+	var params ComponentQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var fieldErr *constants.FieldError
+	purl, err := sbom.ParsePURL(params.PURL)
+	if params.PURL == "" {
+		fieldErr = &constants.FieldError{Field: constants.PURL, Message: constants.ErrFieldRequired}
+	} else if err != nil {
+		fieldErr = &constants.FieldError{Field: constants.PURL, Message: err.Error()}
+	}
+	if validationErr := validation.NewValidationError(fieldErr); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+	purl.Qualifiers = nil
+	purl.Subpath = ""
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	query := h.db.WithContext(ctx).Table("sbom_components").
+		Joins("JOIN versions ON versions.id = sbom_components.version_id AND versions.deleted_at IS NULL").
+		Joins("JOIN services ON services.id = versions.service_id AND services.deleted_at IS NULL").
+		Where("sbom_components.purl_type = ? AND sbom_components.purl_namespace = ? AND sbom_components.purl_name = ?",
+			purl.Type, purl.Namespace, purl.Name)
+	if purl.Version != "" {
+		query = query.Where("sbom_components.version = ?", purl.Version)
+	}
+	if params.ReleasedOnly == constants.True {
+		query = query.Where("versions.status = ?", models.VersionReleased)
+	}
+
+	var totalCount int64
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrComponentsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var rows []struct {
+		ServiceID        uint
+		Service          string
+		VersionID        uint
+		Version          string
+		Status           models.VersionStatus
+		ComponentPURL    string
+		ComponentName    string
+		ComponentVersion string
+		ComponentLicense string
+	}
+	err = query.
+		Select("services.id AS service_id, services.slug AS service, versions.id AS version_id, " +
+			"versions.number AS version, versions.status, sbom_components.purl AS component_purl, " +
+			"sbom_components.name AS component_name, sbom_components.version AS component_version, " +
+			"sbom_components.license AS component_license").
+		Order("services.slug, " + versionPrecedenceOrder(constants.DescSortOrder) + ", sbom_components.id").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Scan(&rows).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrComponentsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	versions := make([]ComponentUsage, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, ComponentUsage{
+			ServiceID: row.ServiceID,
+			Service:   row.Service,
+			VersionID: row.VersionID,
+			Version:   row.Version,
+			Status:    row.Status,
+			Component: models.SBOMComponent{
+				PURL:    row.ComponentPURL,
+				Name:    row.ComponentName,
+				Version: row.ComponentVersion,
+				License: row.ComponentLicense,
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, ListComponentsResponse{
+		PURL:        purl.String(),
+		Versions:    versions,
+		TotalCount:  totalCount,
+		CurrentPage: params.Page,
+		PageSize:    params.PageSize,
+	})
+}
@@ -4,17 +4,26 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/middleware"
 	"serviceCatalog/internal/models"
 )
 
+const testAdminToken = "test-admin-token"
+
 type HandlerTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
@@ -27,30 +36,93 @@ type HandlerTestSuite struct {
 func (s *HandlerTestSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
 	dsn := "host=localhost user=postgres password=postgres dbname=servicecatalog_test port=5432 sslmode=disable"
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		s.T().Fatal(err)
 	}
 	s.db = db
 
-	err = db.AutoMigrate(&models.Service{}, &models.Version{})
+	err = db.AutoMigrate(&models.Team{}, &models.Service{}, &models.Version{}, &models.ServiceLabel{}, &models.AttributeSchema{}, &models.ServiceLink{}, &models.LifecycleTransition{}, &models.ServiceDependency{}, &models.VersionSpec{}, &models.VersionDescriptorSet{}, &models.VersionRequirement{}, &models.VersionSBOM{}, &models.SBOMComponent{})
 	if err != nil {
 		s.T().Fatal(err)
 	}
 
 	s.handler = NewHandler(db)
 	s.router = gin.Default()
+	s.router.Use(middleware.Admin(testAdminToken))
 	s.router.GET("/services", s.handler.ListServices)
+	s.router.POST("/services", s.handler.CreateService)
 	s.router.GET("/services/:id", s.handler.GetService)
+	s.router.PUT("/services/:id", s.handler.UpdateService)
+	s.router.PATCH("/services/:id", s.handler.PatchService)
 	s.router.GET("/services/:id/versions", s.handler.GetServiceVersions)
+	s.router.POST("/services/:id/versions", s.handler.CreateVersion)
+	s.router.DELETE("/services/:id", s.handler.DeleteService)
+	s.router.POST("/services/:id/restore", s.handler.RestoreService)
+	s.router.GET("/services/:id/versions/latest", s.handler.GetLatestVersion)
+	s.router.GET("/services/:id/versions/compare", s.handler.CompareVersions)
+	s.router.GET("/services/:id/versions/:version", s.handler.GetVersion)
+	s.router.DELETE("/services/:id/versions/:version", s.handler.DeleteVersion)
+	s.router.POST("/services/:id/versions/:version/status", s.handler.TransitionVersionStatus)
+	s.router.PUT("/services/:id/versions/:version/notes", s.handler.UpdateReleaseNotes)
+	s.router.GET("/services/:id/versions/:version/spec", s.handler.GetVersionSpec)
+	s.router.PUT("/services/:id/versions/:version/spec", s.handler.PutVersionSpec)
+	s.router.GET("/services/:id/versions/:version/proto", s.handler.GetVersionProto)
+	s.router.PUT("/services/:id/versions/:version/proto", s.handler.PutVersionProto)
+	s.router.GET("/services/:id/versions/:version/proto/compatibility", s.handler.CheckVersionProto)
+	s.router.GET("/services/:id/versions/:version/requires", s.handler.GetVersionRequirements)
+	s.router.PUT("/services/:id/versions/:version/requires", s.handler.ReplaceVersionRequirements)
+	s.router.GET("/services/:id/versions/:version/sbom", s.handler.GetVersionSBOM)
+	s.router.PUT("/services/:id/versions/:version/sbom", s.handler.PutVersionSBOM)
+	s.router.GET("/services/:id/changelog", s.handler.GetServiceChangelog)
+	s.router.GET("/services/:id/dependencies", s.handler.ListServiceDependencies)
+	s.router.POST("/services/:id/dependencies", s.handler.CreateServiceDependency)
+	s.router.DELETE("/services/:id/dependencies/:dependencyId", s.handler.DeleteServiceDependency)
+	s.router.GET("/services/:id/dependents", s.handler.ListServiceDependents)
+	s.router.GET("/services/:id/impact", s.handler.GetServiceImpact)
+	s.router.GET("/services/:id/graph", s.handler.GetServiceGraph)
+	s.router.GET("/graph", s.handler.GetGraph)
+	s.router.GET("/resolve", s.handler.ResolveVersions)
+	s.router.GET("/components", s.handler.ListComponents)
+	s.router.GET("/services/:id/labels", s.handler.GetServiceLabels)
+	s.router.PUT("/services/:id/labels", s.handler.ReplaceServiceLabels)
+	s.router.PATCH("/services/:id/labels", s.handler.PatchServiceLabels)
+	s.router.GET("/services/:id/links", s.handler.ListServiceLinks)
+	s.router.POST("/services/:id/links", s.handler.CreateServiceLink)
+	s.router.GET("/services/:id/links/:linkId", s.handler.GetServiceLink)
+	s.router.PUT("/services/:id/links/:linkId", s.handler.UpdateServiceLink)
+	s.router.DELETE("/services/:id/links/:linkId", s.handler.DeleteServiceLink)
+	s.router.GET("/services/:id/lifecycle", s.handler.GetServiceLifecycle)
+	s.router.POST("/services/:id/lifecycle", s.handler.TransitionServiceLifecycle)
+	s.router.GET("/attributes/schema", s.handler.GetAttributeSchema)
+	s.router.PUT("/attributes/schema", s.handler.PutAttributeSchema)
+	s.router.DELETE("/attributes/schema", s.handler.DeleteAttributeSchema)
+	s.router.GET("/teams", s.handler.ListTeams)
+	s.router.POST("/teams", s.handler.CreateTeam)
+	s.router.GET("/teams/:id", s.handler.GetTeam)
+	s.router.PUT("/teams/:id", s.handler.UpdateTeam)
+	s.router.DELETE("/teams/:id", s.handler.DeleteTeam)
+	s.router.GET("/teams/:id/services", s.handler.ListTeamServices)
 }
 
 func (s *HandlerTestSuite) SetupTest() {
 	// Clean up existing data
+	s.db.Exec("TRUNCATE TABLE service_labels CASCADE")
+	s.db.Exec("TRUNCATE TABLE attribute_schemas CASCADE")
+	s.db.Exec("TRUNCATE TABLE service_links CASCADE")
+	s.db.Exec("TRUNCATE TABLE lifecycle_transitions CASCADE")
+	s.db.Exec("TRUNCATE TABLE service_dependencies CASCADE")
+	s.db.Exec("TRUNCATE TABLE version_specs CASCADE")
+	s.db.Exec("TRUNCATE TABLE version_descriptor_sets CASCADE")
+	s.db.Exec("TRUNCATE TABLE version_requirements CASCADE")
+	s.db.Exec("TRUNCATE TABLE version_sboms CASCADE")
+	s.db.Exec("TRUNCATE TABLE sbom_components CASCADE")
 	s.db.Exec("TRUNCATE TABLE versions CASCADE")
 	s.db.Exec("TRUNCATE TABLE services CASCADE")
+	s.db.Exec("TRUNCATE TABLE teams CASCADE")
 	s.db.Exec("ALTER SEQUENCE services_id_seq RESTART WITH 1")
 	s.db.Exec("ALTER SEQUENCE versions_id_seq RESTART WITH 1")
+	s.db.Exec("ALTER SEQUENCE teams_id_seq RESTART WITH 1")
 
 	// Create test service
 	service := models.Service{
@@ -131,14 +203,1495 @@ func (s *HandlerTestSuite) TestGetServiceVersions() {
 
 	assert.Equal(s.T(), 200, w.Code)
 
-	var versions []models.Version
-	err := json.Unmarshal(w.Body.Bytes(), &versions)
+	var response ListVersionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), uint64(s.testServiceID), response.ServiceID)
+	assert.Equal(s.T(), int64(1), response.TotalCount)
+	assert.Equal(s.T(), 1, len(response.Versions))
+	assert.Equal(s.T(), "1.0.0", response.Versions[0].Number)
+}
+
+func (s *HandlerTestSuite) TestCreateService() {
+	w := httptest.NewRecorder()
+	body := `{"name": "Billing Service", "description": "Generates invoices"}`
+	req, _ := http.NewRequest("POST", "/services", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), 201, w.Code)
+	assert.Equal(s.T(), "/services/2", w.Header().Get("Location"))
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), uint(2), service.ID)
+	assert.Equal(s.T(), "Billing Service", service.Name)
+	assert.Equal(s.T(), 0, service.Versions)
+}
+
+func (s *HandlerTestSuite) TestCreateServiceValidation() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/services", strings.NewReader(`{"name": "  "}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), 422, w.Code)
+
+	var serviceErr constants.ServiceError
+	err := json.Unmarshal(w.Body.Bytes(), &serviceErr)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Len(s.T(), serviceErr.Fields, 1)
+	assert.Equal(s.T(), "name", serviceErr.Fields[0].Field)
+}
+
+func (s *HandlerTestSuite) TestUpdateService() {
+	w := s.request("PUT", "/services/1", "application/json", `{"name": "Renamed Service"}`)
+
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), "Renamed Service", service.Name)
+	assert.Equal(s.T(), "", service.Description)
+	assert.Equal(s.T(), 1, service.Versions)
+}
+
+func (s *HandlerTestSuite) TestPatchServiceMergePatch() {
+	w := s.request("PATCH", "/services/1", "application/merge-patch+json", `{"description": "Patched"}`)
+
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), "Test Service", service.Name)
+	assert.Equal(s.T(), "Patched", service.Description)
+}
+
+func (s *HandlerTestSuite) TestPatchServiceJSONPatch() {
+	body := `[{"op": "test", "path": "/id", "value": 1}, {"op": "replace", "path": "/name", "value": "Patched Service"}]`
+	w := s.request("PATCH", "/services/1", "application/json-patch+json", body)
+
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), "Patched Service", service.Name)
+}
+
+func (s *HandlerTestSuite) TestPatchServiceRejectsReadOnlyAndUnknownFields() {
+	w := s.request("PATCH", "/services/1", "application/merge-patch+json", `{"id": 5, "owner": "team"}`)
+
+	assert.Equal(s.T(), 422, w.Code)
+
+	var serviceErr constants.ServiceError
+	err := json.Unmarshal(w.Body.Bytes(), &serviceErr)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), []constants.FieldError{
+		{Field: "id", Message: constants.ErrFieldReadOnly},
+		{Field: "owner", Message: constants.ErrFieldUnknown},
+	}, serviceErr.Fields)
+
+	w = s.request("PATCH", "/services/1", "application/json-patch+json", `[{"op": "remove", "path": "/created_at"}]`)
+	assert.Equal(s.T(), 422, w.Code)
+}
+
+func (s *HandlerTestSuite) TestPatchServiceUnsupportedContentType() {
+	w := s.request("PATCH", "/services/1", "application/json", `{"name": "x"}`)
+	assert.Equal(s.T(), 415, w.Code)
+}
+
+func (s *HandlerTestSuite) TestCreateVersion() {
+	w := s.request("POST", "/services/1/versions", "application/json", `{"number": "1.1.0"}`)
+
+	assert.Equal(s.T(), 201, w.Code)
+
+	var version models.Version
+	err := json.Unmarshal(w.Body.Bytes(), &version)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), s.testServiceID, version.ServiceID)
+	assert.Equal(s.T(), "1.1.0", version.Number)
+}
+
+func (s *HandlerTestSuite) TestCreateVersionRejectsInvalidAndDuplicateNumbers() {
+	w := s.request("POST", "/services/1/versions", "application/json", `{"number": "1.1"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.0.0"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/99/versions", "application/json", `{"number": "1.0.0"}`)
+	assert.Equal(s.T(), 404, w.Code)
+}
+
+func (s *HandlerTestSuite) TestCreateVersionRejectsDeletedService() {
+	s.db.Delete(&models.Service{}, s.testServiceID)
+
+	w := s.request("POST", "/services/1/versions", "application/json", `{"number": "2.0.0"}`)
+	assert.Equal(s.T(), 409, w.Code)
+}
+
+func (s *HandlerTestSuite) TestRestoreService() {
+	w := s.request("POST", "/services/1/restore", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("DELETE", "/services/1?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/services/1", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("POST", "/services/1/restore", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), "Test Service", service.Name)
+	assert.Equal(s.T(), 1, service.Versions)
+
+	w = s.request("POST", "/services/99/restore", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+}
+
+func (s *HandlerTestSuite) TestPurgeService() {
+	w := s.request("DELETE", "/services/1?purge=true", "", "")
+	assert.Equal(s.T(), 403, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/services/1?purge=true", nil)
+	req.Header.Set(constants.AdminTokenHeader, testAdminToken)
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), 200, w.Code)
+	assert.JSONEq(s.T(), `{"services": 1, "versions": 1}`, w.Body.String())
+
+	var count int64
+	s.db.Unscoped().Model(&models.Service{}).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+	s.db.Unscoped().Model(&models.Version{}).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+}
+
+func (s *HandlerTestSuite) TestDeleteServiceCascadesToVersions() {
+	w := s.request("DELETE", "/services/1?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	var count int64
+	s.db.Model(&models.Version{}).Where("service_id = ?", s.testServiceID).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+	s.db.Unscoped().Model(&models.Version{}).Where("service_id = ?", s.testServiceID).Count(&count)
+	assert.Equal(s.T(), int64(1), count)
+
+	w = s.request("GET", "/services?showDeleted=true", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var response ListServicesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	assert.Equal(s.T(), 1, len(response.Services))
+	assert.Equal(s.T(), 1, response.Services[0].Versions)
+}
+
+func (s *HandlerTestSuite) TestDeleteVersion() {
+	w := s.request("DELETE", "/services/1/versions/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("DELETE", "/services/1/versions/1", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("GET", "/services/1", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), 0, service.Versions)
+
+	w = s.request("GET", "/services", "", "")
+	var response ListServicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), 0, response.Services[0].Versions)
+}
+
+func (s *HandlerTestSuite) TestGetVersion() {
+	for _, path := range []string{"/services/1/versions/1", "/services/1/versions/1.0.0"} {
+		w := s.request("GET", path, "", "")
+		assert.Equal(s.T(), 200, w.Code)
+
+		var version models.Version
+		err := json.Unmarshal(w.Body.Bytes(), &version)
+		if err != nil {
+			s.T().Fatal(err)
+		}
+
+		assert.Equal(s.T(), "1.0.0", version.Number)
+		assert.Equal(s.T(), s.testServiceID, version.ServiceID)
+		assert.False(s.T(), version.CreatedAt.IsZero())
+	}
+
+	w := s.request("GET", "/services/1/versions/9.9.9", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+}
+
+func (s *HandlerTestSuite) TestGetLatestVersion() {
+	for _, number := range []string{"1.10.0", "1.9.0", "2.0.0-rc.1"} {
+		s.db.Create(&models.Version{ServiceID: s.testServiceID, Number: number})
+	}
+
+	w := s.request("GET", "/services/1/versions/latest", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var version models.Version
+	err := json.Unmarshal(w.Body.Bytes(), &version)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "2.0.0-rc.1", version.Number)
+
+	w = s.request("GET", "/services/1/versions/latest?excludePrerelease=true", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	err = json.Unmarshal(w.Body.Bytes(), &version)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "1.10.0", version.Number)
+}
+
+func (s *HandlerTestSuite) TestGetServiceVersionsSemverOrderAndRange() {
+	for _, number := range []string{"1.10.0", "2.0.0", "1.9.0", "2.0.0-rc.1", "1.2.0"} {
+		s.db.Create(&models.Version{ServiceID: s.testServiceID, Number: number})
+	}
+
+	response := s.listVersions("/services/1/versions")
+	assert.Equal(s.T(), []string{"1.0.0", "1.2.0", "1.9.0", "1.10.0", "2.0.0-rc.1", "2.0.0"}, versionNumbers(response))
+
+	response = s.listVersions("/services/1/versions?range=" + url.QueryEscape(">=1.2.0 <2.0.0"))
+	assert.Equal(s.T(), []string{"1.2.0", "1.9.0", "1.10.0"}, versionNumbers(response))
+
+	w := s.request("GET", "/services/1/versions?range=banana", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+func (s *HandlerTestSuite) TestGetServiceVersionsPagination() {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, number := range []string{"1.1.0", "1.2.0", "1.3.0", "1.4.0"} {
+		s.db.Create(&models.Version{ServiceID: s.testServiceID, Number: number, CreatedAt: created.AddDate(0, 0, i)})
+	}
+
+	response := s.listVersions("/services/1/versions?page=2&pageSize=2&sortDir=desc")
+	assert.Equal(s.T(), int64(5), response.TotalCount)
+	assert.Equal(s.T(), 2, response.CurrentPage)
+	assert.Equal(s.T(), []string{"1.2.0", "1.1.0"}, versionNumbers(response))
+
+	response = s.listVersions("/services/1/versions?sortBy=created_at&createdAfter=2024-01-01T12:00:00Z&createdBefore=2024-01-04T00:00:00Z")
+	assert.Equal(s.T(), int64(2), response.TotalCount)
+	assert.Equal(s.T(), []string{"1.2.0", "1.3.0"}, versionNumbers(response))
+
+	w := s.request("GET", "/services/1/versions?pageSize=1000", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	w = s.request("GET", "/services/1/versions?sortBy=name", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+func (s *HandlerTestSuite) TestVersionStatus() {
+	w := s.request("POST", "/services/1/versions", "application/json", `{"number": "1.1.0", "status": "draft"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.2.0", "status": "yanked"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("GET", "/services/1/versions/latest", "", "")
+	var latest models.Version
+	err := json.Unmarshal(w.Body.Bytes(), &latest)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "1.0.0", latest.Number)
+
+	w = s.request("GET", "/services?releasedOnly=true", "", "")
+	var services ListServicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &services)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), 1, services.Services[0].Versions)
+
+	w = s.request("POST", "/services/1/versions/1.1.0/status", "application/json", `{"status": "deprecated"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.1.0/status", "application/json", `{"status": "released"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json",
+		`{"status": "deprecated", "deprecation_date": "2024-06-01T00:00:00Z", "end_of_life_date": "2024-01-01T00:00:00Z"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json",
+		`{"status": "deprecated", "end_of_life_date": "2099-12-31T00:00:00Z"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	var version models.Version
+	err = json.Unmarshal(w.Body.Bytes(), &version)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), models.VersionDeprecated, version.Status)
+	assert.NotNil(s.T(), version.DeprecationDate)
+	if assert.NotNil(s.T(), version.EndOfLifeDate) {
+		assert.Equal(s.T(), 2099, version.EndOfLifeDate.Year())
+	}
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json", `{"status": "end-of-life"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json", `{"status": "released"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.1.0/status", "application/json",
+		`{"status": "yanked", "deprecation_date": "2024-06-01T00:00:00Z"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	response := s.listVersions("/services/1/versions?status=released,deprecated")
+	assert.Equal(s.T(), []string{"1.1.0"}, versionNumbers(response))
+
+	response = s.listVersions("/services/1/versions?status=end-of-life")
+	assert.Equal(s.T(), []string{"1.0.0"}, versionNumbers(response))
+
+	w = s.request("GET", "/services/1/versions?status=stable", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceChangelog() {
+	w := s.request("POST", "/services/1/versions", "application/json",
+		`{"number": "1.10.0", "release_notes": "### Added\n\n- Refunds"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.9.0"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "2.0.0", "status": "draft"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("PUT", "/services/1/versions/1.9.0/notes", "application/json",
+		`{"release_notes": "<script>alert(1)</script>Fixed rounding"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("PUT", "/services/1/versions/1.9.0/notes", "application/json",
+		`{"release_notes": "`+strings.Repeat("a", 20001)+`"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("GET", "/services/test-service/changelog", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var response ChangelogResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	versions := []string{}
+	for _, entry := range response.Entries {
+		versions = append(versions, entry.Version)
+	}
+	assert.Equal(s.T(), []string{"1.10.0", "1.9.0", "1.0.0"}, versions)
+	assert.Equal(s.T(), "### Added\n\n- Refunds", response.Entries[0].Notes)
+
+	w = s.acceptRequest("/services/1/changelog", "text/markdown")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "text/markdown")
+	assert.True(s.T(), strings.HasPrefix(w.Body.String(), "# Test Service changelog\n\n## 1.10.0 ("))
+
+	w = s.acceptRequest("/services/1/changelog", "text/html,application/xhtml+xml,*/*;q=0.8")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(s.T(), w.Body.String(), "<li>Refunds</li>")
+	assert.NotContains(s.T(), w.Body.String(), "<script>")
+}
+
+func (s *HandlerTestSuite) TestVersionSpec() {
+	const spec = "openapi: 3.0.3\ninfo:\n  title: Test API\n  version: \"1.0\"\npaths:\n  /ping:\n    get:\n      responses:\n        \"200\":\n          description: pong\n"
+
+	w := s.request("GET", "/services/1/versions/1.0.0/spec", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("GET", "/services/1", "", "")
+	assert.Contains(s.T(), w.Body.String(), `"latest_version_has_spec":false`)
+
+	w = s.request("PUT", "/services/1/versions/1.0.0/spec", "application/yaml", "openapi: [")
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("PUT", "/services/1/versions/1.0.0/spec", "application/json",
+		`{"openapi": "3.0.3", "info": {"title": "Test API"}, "paths": {"/ping": {"get": {}}}}`)
+	assert.Equal(s.T(), 422, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"field":"spec.info.version"`)
+	assert.Contains(s.T(), w.Body.String(), `"field":"spec.paths./ping.get.responses"`)
+
+	w = s.request("PUT", "/services/1/versions/1.0.0/spec", "application/yaml", spec)
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"openapi":"3.0.3"`)
+	assert.Contains(s.T(), w.Body.String(), `"format":"yaml"`)
+
+	w = s.request("GET", "/services/test-service/versions/1.0.0/spec", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "application/yaml")
+	assert.Equal(s.T(), spec, w.Body.String())
+
+	w = s.acceptRequest("/services/1/versions/1.0.0/spec", "application/json")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "application/json")
+	var document map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &document)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "3.0.3", document["openapi"])
+
+	w = s.request("GET", "/services/1", "", "")
+	assert.Contains(s.T(), w.Body.String(), `"latest_version_has_spec":true`)
+	w = s.request("GET", "/services", "", "")
+	assert.Contains(s.T(), w.Body.String(), `"latest_version_has_spec":true`)
+
+	// A newer version without a specification becomes the latest
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.1.0"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("GET", "/services/1", "", "")
+	assert.Contains(s.T(), w.Body.String(), `"latest_version_has_spec":false`)
+}
+
+func (s *HandlerTestSuite) TestVersionCompare() {
+	const v1 = `{"openapi": "3.0.3", "info": {"title": "Test API", "version": "1"}, "paths": {
+		"/ping": {"get": {"responses": {"200": {"description": "pong"}}}},
+		"/status": {"get": {"responses": {"200": {"description": "ok"}}}}}}`
+	const v2 = `{"openapi": "3.0.3", "info": {"title": "Test API", "version": "2"}, "paths": {
+		"/ping": {"get": {"responses": {"200": {"description": "pong"}}}},
+		"/health": {"get": {"responses": {"200": {"description": "ok"}}}}}}`
+
+	w := s.request("PUT", "/services/1/versions/1.0.0/spec", "application/json", v1)
+	assert.Equal(s.T(), 200, w.Code)
+	for _, number := range []string{"1.1.0", "2.0.0"} {
+		w = s.request("POST", "/services/1/versions", "application/json", `{"number": "`+number+`", "status": "draft"}`)
+		assert.Equal(s.T(), 201, w.Code)
+		w = s.request("PUT", "/services/1/versions/"+number+"/spec", "application/json", v2)
+		assert.Equal(s.T(), 200, w.Code)
+	}
+
+	w = s.request("GET", "/services/1/versions/compare?from=1.0.0", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	w = s.request("GET", "/services/test-service/versions/compare?from=1.0.0&to=1.1.0", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	var response CompareVersionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	if err != nil {
 		s.T().Fatal(err)
 	}
+	assert.True(s.T(), response.Breaking)
+	assert.False(s.T(), response.MajorIncreased)
+	if assert.Len(s.T(), response.Changes, 2) {
+		assert.Equal(s.T(), "path-removed", string(response.Changes[0].Kind))
+		assert.Equal(s.T(), "/status", response.Changes[0].Path)
+		assert.True(s.T(), response.Changes[0].Breaking)
+		assert.Equal(s.T(), "path-added", string(response.Changes[1].Kind))
+		assert.False(s.T(), response.Changes[1].Breaking)
+	}
+
+	// Publishing the breaking minor version is refused on request only
+	w = s.request("POST", "/services/1/versions/1.1.0/status?rejectBreaking=true", "application/json", `{"status": "released"}`)
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "path /status was removed")
+
+	w = s.request("POST", "/services/1/versions/2.0.0/status?rejectBreaking=true", "application/json", `{"status": "released"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("POST", "/services/1/versions/1.1.0/status", "application/json", `{"status": "released"}`)
+	assert.Equal(s.T(), 200, w.Code)
 
-	assert.Equal(s.T(), 1, len(versions))
-	assert.Equal(s.T(), "1.0.0", versions[0].Number)
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "2.1.0", "status": "draft"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("GET", "/services/1/versions/compare?from=2.0.0&to=2.1.0", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	// Versions released on creation are checked when their specification is uploaded
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.2.0"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("PUT", "/services/1/versions/1.2.0/spec?rejectBreaking=true", "application/json", v1)
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "path /health was removed")
+	w = s.request("GET", "/services/1/versions/1.2.0/spec", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+	w = s.request("PUT", "/services/1/versions/1.2.0/spec?rejectBreaking=true", "application/json", v2)
+	assert.Equal(s.T(), 200, w.Code)
+	w = s.request("PUT", "/services/1/versions/1.2.0/spec", "application/json", v1)
+	assert.Equal(s.T(), 200, w.Code)
+}
+
+func (s *HandlerTestSuite) TestVersionProto() {
+	descriptorSet := func(fields ...string) string {
+		message := &descriptorpb.DescriptorProto{Name: proto.String("Ping")}
+		for i, name := range fields {
+			message.Field = append(message.Field, &descriptorpb.FieldDescriptorProto{
+				Name:   proto.String(name),
+				Number: proto.Int32(int32(i + 1)),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			})
+		}
+		data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+			Name:        proto.String("test/v1/ping.proto"),
+			Package:     proto.String("test.v1"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{message},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("PingService"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Ping"),
+					InputType:  proto.String(".test.v1.Ping"),
+					OutputType: proto.String(".test.v1.Ping"),
+				}},
+			}},
+		}}})
+		if err != nil {
+			s.T().Fatal(err)
+		}
+		return string(data)
+	}
+
+	w := s.request("GET", "/services/1/versions/1.0.0/proto", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("PUT", "/services/1/versions/1.0.0/proto", "application/x-protobuf", "not a descriptor set")
+	assert.Equal(s.T(), 422, w.Code)
+
+	v1 := descriptorSet("message", "trace_id")
+	w = s.request("PUT", "/services/1/versions/1.0.0/proto", "application/x-protobuf", v1)
+	assert.Equal(s.T(), 200, w.Code)
+	var described DescriptorSetResponse
+	err := json.Unmarshal(w.Body.Bytes(), &described)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), []string{"test/v1/ping.proto"}, described.Files)
+	if assert.Len(s.T(), described.Services, 1) {
+		assert.Equal(s.T(), "test.v1.PingService", described.Services[0].Name)
+		assert.Equal(s.T(), "test.v1.Ping", described.Services[0].Methods[0].InputType)
+	}
+
+	w = s.acceptRequest("/services/test-service/versions/1.0.0/proto", "application/x-protobuf")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Equal(s.T(), v1, w.Body.String())
+
+	// Without an earlier published version there is nothing to break
+	w = s.request("GET", "/services/1/versions/1.0.0/proto/compatibility", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"compatible":true`)
+	assert.NotContains(s.T(), w.Body.String(), `"against"`)
+
+	w = s.request("POST", "/services/1/versions", "application/json", `{"number": "1.1.0", "status": "draft"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("GET", "/services/1/versions/1.1.0/proto/compatibility", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("PUT", "/services/1/versions/1.1.0/proto", "application/x-protobuf", descriptorSet("message"))
+	assert.Equal(s.T(), 200, w.Code)
+	w = s.request("GET", "/services/1/versions/1.1.0/proto/compatibility", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	var response CompatibilityResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "1.0.0", response.Against)
+	assert.False(s.T(), response.Compatible)
+	if assert.Len(s.T(), response.Changes, 1) {
+		assert.Equal(s.T(), "field-removed", string(response.Changes[0].Kind))
+		assert.Equal(s.T(), "test.v1.Ping.trace_id", response.Changes[0].Element)
+	}
+
+	w = s.request("GET", "/services/1/versions/1.1.0/proto/compatibility?against=1.1.0", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"compatible":true`)
+}
+
+func (s *HandlerTestSuite) TestVersionRequirements() {
+	w := s.request("POST", "/services", "application/json", `{"name": "Checkout"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("POST", "/services/checkout/versions", "application/json",
+		`{"number": "3.0.0", "requires": {"test-service": "^1.0.0"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"requires":[{"service_id":1,"service":"test-service","range":"^1.0.0"}]`)
+
+	w = s.request("PUT", "/services/checkout/versions/3.0.0/requires", "application/json",
+		`{"test-service": "^one", "checkout": "^3.0.0", "missing": "1.x"}`)
+	assert.Equal(s.T(), 422, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"field":"requires.test-service"`)
+	assert.Contains(s.T(), w.Body.String(), `"field":"requires.checkout"`)
+	assert.Contains(s.T(), w.Body.String(), `"field":"requires.missing"`)
+
+	w = s.request("GET", "/services/checkout/versions/3.0.0/requires", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"requires":[{"service_id":1,"service":"test-service","range":"^1.0.0"}]`)
+
+	w = s.request("GET", "/services/checkout/versions/3.0.0", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"requires":[{"service_id":1,"service":"test-service","range":"^1.0.0"}]`)
+
+	w = s.request("GET", "/resolve?services=checkout", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	var response ResolveResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.True(s.T(), response.Resolved)
+	assert.Equal(s.T(), []ResolvedVersion{
+		{ServiceID: 2, Service: "checkout", VersionID: 2, Version: "3.0.0", Requested: true},
+		{ServiceID: 1, Service: "test-service", VersionID: 1, Version: "1.0.0", Requested: false},
+	}, response.Versions)
+
+	// The newest checkout needs a test-service version that does not exist
+	w = s.request("POST", "/services/checkout/versions", "application/json",
+		`{"number": "3.1.0", "requires": {"1": ">=2.0.0 <3.0.0"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("GET", "/resolve?services=checkout,test-service", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"version":"3.0.0"`)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Orders"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/orders/versions", "application/json",
+		`{"number": "1.0.0", "requires": {"checkout": ">=3.1.0"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("GET", "/resolve?services=checkout,orders", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	response = ResolveResponse{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.False(s.T(), response.Resolved)
+	if assert.NotNil(s.T(), response.Conflict) {
+		assert.Equal(s.T(), "test-service", response.Conflict.Service)
+		assert.Equal(s.T(), []ConflictRequirement{{Service: "checkout", Version: "3.1.0", Range: ">=2.0.0 <3.0.0"}}, response.Conflict.RequiredBy)
+		assert.Equal(s.T(), []RejectedVersion{{Version: "1.0.0", Reason: "checkout@3.1.0 requires >=2.0.0 <3.0.0"}}, response.Conflict.Rejected)
+	}
+
+	w = s.request("GET", "/resolve?services=checkout,unknown", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+	w = s.request("GET", "/resolve?services=", "", "")
+	assert.Equal(s.T(), 422, w.Code)
+
+	// checkout@3.0.0 is released and only test-service 1.0.0 satisfies it
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json", `{"status": "deprecated"}`)
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "checkout@3.0.0 (^1.0.0)")
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json", `{"status": "yanked"}`)
+	assert.Equal(s.T(), 409, w.Code)
+	w = s.request("DELETE", "/services/1/versions/1.0.0", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "checkout@3.0.0 (^1.0.0)")
+
+	w = s.request("POST", "/services/1/versions/1.0.0/status?force=true", "application/json", `{"status": "deprecated"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	// Still the only version checkout@3.0.0 can use
+	w = s.request("POST", "/services/1/versions/1.0.0/status", "application/json", `{"status": "yanked"}`)
+	assert.Equal(s.T(), 409, w.Code)
+	w = s.request("DELETE", "/services/1/versions/1.0.0?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+}
+
+func (s *HandlerTestSuite) TestVersionSBOM() {
+	const cycloneDX = `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"components": [
+			{
+				"name": "log4j-core",
+				"version": "2.14.1",
+				"purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+				"licenses": [{"license": {"id": "Apache-2.0"}}]
+			},
+			{"name": "gin", "version": "v1.9.1", "purl": "pkg:golang/github.com/gin-gonic/gin@v1.9.1"}
+		]
+	}`
+	const spdx = `{
+		"spdxVersion": "SPDX-2.3",
+		"packages": [{
+			"SPDXID": "SPDXRef-log4j",
+			"name": "log4j-core",
+			"versionInfo": "2.17.1",
+			"licenseConcluded": "Apache-2.0",
+			"externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1"}]
+		}]
+	}`
+
+	w := s.request("PUT", "/services/1/versions/1.0.0/sbom", "application/json", cycloneDX)
+	assert.Equal(s.T(), 200, w.Code)
+	var response SBOMResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "cyclonedx", response.Format)
+	assert.Equal(s.T(), "1.5", response.SpecVersion)
+	assert.Equal(s.T(), []models.SBOMComponent{
+		{PURL: "pkg:golang/github.com/gin-gonic/gin@v1.9.1", Name: "gin", Version: "v1.9.1"},
+		{PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Name: "log4j-core", Version: "2.14.1", License: "Apache-2.0"},
+	}, response.Components)
+
+	w = s.request("GET", "/services/test-service/versions/1.0.0/sbom", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"license":"Apache-2.0"`)
+
+	w = s.request("PUT", "/services/1/versions/1.0.0/sbom", "application/json", `{"openapi": "3.0.3"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Ledger"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/ledger/versions", "application/json", `{"number": "2.0.0"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("GET", "/services/ledger/versions/2.0.0/sbom", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+	w = s.request("PUT", "/services/ledger/versions/2.0.0/sbom", "application/json", spdx)
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"format":"spdx"`)
+
+	w = s.request("GET", "/components?purl=pkg:maven/org.apache.logging.log4j/log4j-core", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	var components ListComponentsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &components)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "pkg:maven/org.apache.logging.log4j/log4j-core", components.PURL)
+	assert.Equal(s.T(), int64(2), components.TotalCount)
+	if assert.Len(s.T(), components.Versions, 2) {
+		assert.Equal(s.T(), "ledger", components.Versions[0].Service)
+		assert.Equal(s.T(), "2.17.1", components.Versions[0].Component.Version)
+		assert.Equal(s.T(), "test-service", components.Versions[1].Service)
+		assert.Equal(s.T(), "1.0.0", components.Versions[1].Version)
+		assert.Equal(s.T(), "2.14.1", components.Versions[1].Component.Version)
+	}
+
+	w = s.request("GET", "/components?purl=pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"total_count":1`)
+	assert.Contains(s.T(), w.Body.String(), `"service":"test-service"`)
+
+	w = s.request("GET", "/components", "", "")
+	assert.Equal(s.T(), 422, w.Code)
+	w = s.request("GET", "/components?purl=log4j-core", "", "")
+	assert.Equal(s.T(), 422, w.Code)
+
+	// Uploading another SBOM replaces the components of the version
+	w = s.request("PUT", "/services/1/versions/1.0.0/sbom", "application/json", spdx)
+	assert.Equal(s.T(), 200, w.Code)
+	w = s.request("GET", "/components?purl=pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"total_count":0`)
+}
+
+// listVersions fetches a page of versions and decodes the response.
+func (s *HandlerTestSuite) listVersions(path string) ListVersionsResponse {
+	w := s.request("GET", path, "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var response ListVersionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	return response
+}
+
+func versionNumbers(response ListVersionsResponse) []string {
+	numbers := []string{}
+	for _, version := range response.Versions {
+		numbers = append(numbers, version.Number)
+	}
+	return numbers
+}
+
+func (s *HandlerTestSuite) TestServiceSlugs() {
+	w := s.request("GET", "/services/test-service", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), uint(1), service.ID)
+	assert.Equal(s.T(), "test-service", service.Slug)
+
+	w = s.request("GET", "/services/test-service/versions", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("GET", "/services/unknown-service", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Test Service"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Test Service", "slug": "test-service-eu"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("PATCH", "/services/test-service-eu", "application/merge-patch+json", `{"slug": "test-service"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Other", "slug": "Not A Slug"}`)
+	assert.Equal(s.T(), 422, w.Code)
+}
+
+func (s *HandlerTestSuite) TestTeams() {
+	w := s.request("POST", "/teams", "application/json",
+		`{"name": "Payments", "contact_email": "payments@example.com", "chat_channel": "#payments"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	assert.Equal(s.T(), "/teams/1", w.Header().Get("Location"))
+
+	w = s.request("POST", "/teams", "application/json", `{"name": "Payments"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/teams", "application/json", `{"name": "", "contact_email": "not-an-email"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("PUT", "/teams/1", "application/json",
+		`{"name": "Payments", "contact_email": "payments@example.com", "chat_channel": "#payments-oncall"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("GET", "/teams/1", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var team models.Team
+	err := json.Unmarshal(w.Body.Bytes(), &team)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "#payments-oncall", team.ChatChannel)
+
+	w = s.request("GET", "/teams?search=pay", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var teams ListTeamsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &teams)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), int64(1), teams.TotalCount)
+
+	w = s.request("DELETE", "/teams/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/teams/1", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceOwner() {
+	w := s.request("POST", "/teams", "application/json", `{"name": "Payments", "chat_channel": "#payments"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Billing", "owner_id": 42}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Billing", "owner_id": 1}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("GET", "/services/billing", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if assert.NotNil(s.T(), service.Owner) {
+		assert.Equal(s.T(), "Payments", service.Owner.Name)
+		assert.Equal(s.T(), "#payments", service.Owner.ChatChannel)
+	}
+
+	for _, path := range []string{"/services?owner=1", "/services?owner=Payments", "/teams/1/services"} {
+		w = s.request("GET", path, "", "")
+		assert.Equal(s.T(), 200, w.Code, path)
+
+		var response ListServicesResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		if err != nil {
+			s.T().Fatal(err)
+		}
+		if assert.Equal(s.T(), 1, len(response.Services), path) {
+			assert.Equal(s.T(), "Billing", response.Services[0].Name)
+			assert.Equal(s.T(), "Payments", response.Services[0].Owner.Name)
+		}
+	}
+
+	w = s.request("DELETE", "/teams/1", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("PATCH", "/services/billing", "application/merge-patch+json", `{"owner_id": null}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("DELETE", "/teams/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceLabels() {
+	w := s.request("PUT", "/services/1/labels", "application/json", `{"tier": "critical", "language": "go"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("PATCH", "/services/1/labels", "application/json", `{"domain": "payments", "language": null}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	var labels LabelsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &labels)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), map[string]string{"tier": "critical", "domain": "payments"}, labels.Labels)
+
+	w = s.request("PUT", "/services/1/labels", "application/json", `{"bad key": "x"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services", "application/json",
+		`{"name": "Billing", "labels": {"tier": "low", "domain": "billing", "language": "java"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("GET", "/services/1", "", "")
+	var service models.ServiceResponse
+	err = json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), "critical", service.Labels["tier"])
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"tier=critical", []string{"Test Service"}},
+		{"language!=java", []string{"Test Service"}},
+		{"domain in (payments,billing)", []string{"Test Service", "Billing"}},
+		{"domain notin (payments)", []string{"Billing"}},
+		{"language", []string{"Billing"}},
+		{"!language,tier", []string{"Test Service"}},
+	}
+	for _, tt := range tests {
+		w = s.request("GET", "/services?selector="+url.QueryEscape(tt.selector), "", "")
+		assert.Equal(s.T(), 200, w.Code, tt.selector)
+
+		var response ListServicesResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		if err != nil {
+			s.T().Fatal(err)
+		}
+		var names []string
+		for _, service := range response.Services {
+			names = append(names, service.Name)
+		}
+		assert.Equal(s.T(), tt.expected, names, tt.selector)
+	}
+
+	w = s.request("GET", "/services?selector="+url.QueryEscape("tier in critical"), "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceAttributes() {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"costCenter": {"type": "string", "pattern": "^[0-9]+$"},
+			"classification": {"enum": ["public", "internal", "confidential"]}
+		},
+		"additionalProperties": false
+	}`
+	w := s.request("PUT", "/attributes/schema", "application/json", schema)
+	assert.Equal(s.T(), 403, w.Code)
+
+	w = s.adminRequest("PUT", "/attributes/schema", "application/json", `{"type": "no-such-type"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.adminRequest("PUT", "/attributes/schema", "application/json", schema)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("GET", "/attributes/schema", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("POST", "/services", "application/json",
+		`{"name": "Billing", "attributes": {"costCenter": "forty-two", "classification": "secret"}}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	var validationErr constants.ServiceError
+	err := json.Unmarshal(w.Body.Bytes(), &validationErr)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	fields := []string{}
+	for _, field := range validationErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(s.T(), []string{"attributes.classification", "attributes.costCenter"}, fields)
+
+	w = s.request("POST", "/services", "application/json",
+		`{"name": "Billing", "attributes": {"costCenter": "42", "classification": "internal"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("PATCH", "/services/1", "application/json-patch+json",
+		`[{"op": "add", "path": "/attributes/costCenter", "value": "7"}]`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("PATCH", "/services/1", "application/merge-patch+json", `{"attributes": {"owner": "me"}}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	var service models.ServiceResponse
+	w = s.request("GET", "/services/1", "", "")
+	err = json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), models.Attributes{"costCenter": "7"}, service.Attributes)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"attr.costCenter=42", []string{"Billing"}},
+		{"attr.costCenter=42&attr.costCenter=7", []string{"Test Service", "Billing"}},
+		{"attr.costCenter=42&attr.classification=public", nil},
+		{"attr.classification=internal", []string{"Billing"}},
+	}
+	for _, tt := range tests {
+		w = s.request("GET", "/services?"+tt.query, "", "")
+		assert.Equal(s.T(), 200, w.Code, tt.query)
+
+		var response ListServicesResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		if err != nil {
+			s.T().Fatal(err)
+		}
+		var names []string
+		for _, service := range response.Services {
+			names = append(names, service.Name)
+		}
+		assert.Equal(s.T(), tt.expected, names, tt.query)
+	}
+
+	w = s.request("GET", "/services?attr..x=1", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	w = s.adminRequest("DELETE", "/attributes/schema", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("PATCH", "/services/1", "application/merge-patch+json", `{"attributes": {"owner": "me"}}`)
+	assert.Equal(s.T(), 200, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceLinks() {
+	w := s.request("POST", "/services/1/links", "application/json",
+		`{"type": "runbook", "url": "https://wiki.example.com/test/oncall", "title": "On-call"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	assert.Equal(s.T(), "/services/1/links/1", w.Header().Get("Location"))
+
+	w = s.request("POST", "/services/1/links", "application/json",
+		`{"type": "runbook", "url": "https://wiki.example.com/test/oncall"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/1/links", "application/json", `{"type": "wiki", "url": "not a url"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/1/links", "application/json",
+		`{"type": "repository", "url": "https://github.com/example/test"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("PUT", "/services/1/links/2", "application/json",
+		`{"type": "repository", "url": "https://github.com/example/test-service"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("GET", "/services/1", "", "")
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if assert.Len(s.T(), service.Links, 2) {
+		assert.Equal(s.T(), models.LinkRepository, service.Links[0].Type)
+		assert.Equal(s.T(), "https://github.com/example/test-service", service.Links[0].URL)
+		assert.Equal(s.T(), models.LinkRunbook, service.Links[1].Type)
+	}
+
+	w = s.request("GET", "/services/1/links?type=runbook", "", "")
+	var links LinksResponse
+	err = json.Unmarshal(w.Body.Bytes(), &links)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Len(s.T(), links.Links, 1)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Billing"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("GET", "/services?missingLink=runbook", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	var response ListServicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if assert.Len(s.T(), response.Services, 1) {
+		assert.Equal(s.T(), "Billing", response.Services[0].Name)
+	}
+
+	w = s.request("GET", "/services?missingLink=wiki", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	w = s.request("DELETE", "/services/1/links/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/services/2/links/2", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceLifecycle() {
+	w := s.request("POST", "/services", "application/json", `{"name": "Billing"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	var service models.ServiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &service)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), models.LifecycleProposed, service.Lifecycle)
+
+	w = s.request("POST", "/services", "application/json", `{"name": "Legacy Billing", "lifecycle": "retired"}`)
+	assert.Equal(s.T(), 422, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"field":"lifecycle"`)
+
+	w = s.request("POST", "/services/billing/lifecycle", "application/json", `{"stage": "experimental"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/billing/lifecycle", "application/json",
+		`{"stage": "experimental", "actor": "jane@example.com", "reason": "Pilot with one merchant"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("POST", "/services/1/lifecycle", "application/json",
+		`{"stage": "deprecated", "actor": "jane@example.com"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	w = s.request("DELETE", "/services/1", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/1/lifecycle", "application/json",
+		`{"stage": "retired", "actor": "jane@example.com", "reason": "Traffic moved to Billing"}`)
+	assert.Equal(s.T(), 200, w.Code)
+
+	var lifecycle LifecycleResponse
+	err = json.Unmarshal(w.Body.Bytes(), &lifecycle)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), models.LifecycleRetired, lifecycle.Stage)
+	assert.Empty(s.T(), lifecycle.Next)
+	if assert.Len(s.T(), lifecycle.History, 2) {
+		assert.Equal(s.T(), models.LifecycleDeprecated, lifecycle.History[0].From)
+		assert.Equal(s.T(), models.LifecycleRetired, lifecycle.History[0].To)
+		assert.Equal(s.T(), "Traffic moved to Billing", lifecycle.History[0].Reason)
+	}
+
+	w = s.request("POST", "/services/1/lifecycle", "application/json",
+		`{"stage": "production", "actor": "jane@example.com"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("PATCH", "/services/1", "application/merge-patch+json", `{"lifecycle": "production"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("GET", "/services?lifecycle=experimental,retired", "", "")
+	var response ListServicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), int64(2), response.TotalCount)
+
+	w = s.request("GET", "/services?lifecycle=live", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	w = s.request("DELETE", "/services/billing", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("DELETE", "/services/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("DELETE", "/services/billing?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+}
+
+func (s *HandlerTestSuite) TestServiceDependencies() {
+	w := s.request("POST", "/services", "application/json", `{"name": "Payment Gateway", "lifecycle": "production"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 1, "kind": "sync-http", "criticality": "high"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	assert.Equal(s.T(), "/services/2/dependencies/1", w.Header().Get("Location"))
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 1, "kind": "sync-http"}`)
+	assert.Equal(s.T(), 409, w.Code)
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 1, "kind": "async-queue"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 2, "kind": "database"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 99, "kind": "database"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("POST", "/services/payment-gateway/dependencies", "application/json",
+		`{"depends_on_id": 1, "kind": "grpc", "criticality": "extreme"}`)
+	assert.Equal(s.T(), 422, w.Code)
+
+	w = s.request("GET", "/services/payment-gateway/dependencies", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var dependencies DependenciesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &dependencies)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if assert.Len(s.T(), dependencies.Dependencies, 2) {
+		assert.Equal(s.T(), "test-service", dependencies.Dependencies[0].Service.Slug)
+		assert.Equal(s.T(), models.CriticalityHigh, dependencies.Dependencies[0].Criticality)
+	}
+
+	w = s.request("GET", "/services/1/dependents?kind=async-queue", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var dependents DependentsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &dependents)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if assert.Len(s.T(), dependents.Dependents, 1) {
+		assert.Equal(s.T(), "payment-gateway", dependents.Dependents[0].Service.Slug)
+		assert.Equal(s.T(), models.DependencyAsyncQueue, dependents.Dependents[0].Kind)
+	}
+
+	w = s.request("GET", "/services/1/dependents?kind=grpc", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+
+	s.db.Model(&models.Service{}).Where("id = ?", 1).UpdateColumn("lifecycle", models.LifecycleRetired)
+	w = s.request("DELETE", "/services/1", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "payment-gateway")
+
+	w = s.request("DELETE", "/services/payment-gateway/dependencies/1", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+	w = s.request("DELETE", "/services/payment-gateway/dependencies/1", "", "")
+	assert.Equal(s.T(), 404, w.Code)
+
+	// Edges of a deleted service can be neither added nor removed
+	s.db.Delete(&models.Service{}, 2)
+	w = s.request("POST", "/services/2/dependencies", "application/json", `{"depends_on_id": 1, "kind": "database"}`)
+	assert.Equal(s.T(), 409, w.Code)
+	w = s.request("DELETE", "/services/2/dependencies/2", "", "")
+	assert.Equal(s.T(), 409, w.Code)
+	assert.Contains(s.T(), w.Body.String(), constants.ErrServiceDeleted)
+	s.db.Unscoped().Model(&models.Service{}).Where("id = ?", 2).Update("deleted_at", nil)
+
+	w = s.request("DELETE", "/services/1?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/services/payment-gateway/dependencies", "", "")
+	err = json.Unmarshal(w.Body.Bytes(), &dependencies)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Empty(s.T(), dependencies.Dependencies)
+}
+
+func (s *HandlerTestSuite) TestServiceImpact() {
+	for _, name := range []string{"Payments", "Checkout", "Reporting"} {
+		w := s.request("POST", "/services", "application/json", `{"name": "`+name+`"}`)
+		assert.Equal(s.T(), 201, w.Code)
+	}
+
+	// payments -> test-service, checkout -> payments (twice), reporting -> checkout,
+	// and test-service -> checkout closing a cycle
+	for _, edge := range []struct {
+		from, body string
+	}{
+		{"payments", `{"depends_on_id": 1, "kind": "sync-http"}`},
+		{"checkout", `{"depends_on_id": 2, "kind": "sync-http"}`},
+		{"checkout", `{"depends_on_id": 2, "kind": "async-queue"}`},
+		{"reporting", `{"depends_on_id": 3, "kind": "database"}`},
+		{"test-service", `{"depends_on_id": 3, "kind": "async-queue"}`},
+	} {
+		w := s.request("POST", "/services/"+edge.from+"/dependencies", "application/json", edge.body)
+		assert.Equal(s.T(), 201, w.Code)
+	}
+
+	w := s.request("GET", "/services/test-service/impact", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+
+	var response ImpactResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Equal(s.T(), 5, response.Depth)
+	if assert.Len(s.T(), response.Affected, 3) {
+		assert.Equal(s.T(), "payments", response.Affected[0].Service.Slug)
+		assert.Equal(s.T(), 1, response.Affected[0].Distance)
+		assert.Equal(s.T(), []string{"test-service", "payments", "checkout", "reporting"}, response.Affected[2].Path)
+		assert.Equal(s.T(), 3, response.Affected[2].Distance)
+	}
+	assert.Equal(s.T(), [][]string{{"test-service", "payments", "checkout", "test-service"}}, response.Cycles)
+
+	w = s.request("GET", "/services/1/impact?depth=1", "", "")
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Len(s.T(), response.Affected, 1)
+	assert.Empty(s.T(), response.Cycles)
+
+	w = s.request("DELETE", "/services/checkout?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/services/1/impact", "", "")
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	assert.Len(s.T(), response.Affected, 1)
+
+	w = s.request("GET", "/services/1/impact?depth=0", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+	w = s.request("GET", "/services/1/impact?depth=11", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+func (s *HandlerTestSuite) TestGraph() {
+	w := s.request("POST", "/services", "application/json", `{"name": "Payments", "labels": {"domain": "payments"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services", "application/json", `{"name": "Ledger", "labels": {"domain": "payments"}}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/payments/dependencies", "application/json", `{"depends_on_id": 1, "kind": "sync-http"}`)
+	assert.Equal(s.T(), 201, w.Code)
+	w = s.request("POST", "/services/ledger/dependencies", "application/json", `{"depends_on_id": 2, "kind": "database", "criticality": "high"}`)
+	assert.Equal(s.T(), 201, w.Code)
+
+	w = s.request("GET", "/graph", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "text/vnd.graphviz")
+	assert.Contains(s.T(), w.Body.String(), `s1 [label="Test Service\n1 version"];`)
+	assert.Contains(s.T(), w.Body.String(), `s2 -> s1 [label="sync-http"];`)
+	assert.Contains(s.T(), w.Body.String(), `s3 -> s2 [label="database, high"];`)
+
+	w = s.request("GET", "/graph?format=mermaid&selector=domain%3Dpayments", "", "")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.NotContains(s.T(), w.Body.String(), "Test Service")
+	assert.NotContains(s.T(), w.Body.String(), "s2 -->|sync-http| s1")
+	assert.Contains(s.T(), w.Body.String(), "s3 -->|database, high| s2")
+
+	w = s.acceptRequest("/services/ledger/graph", "application/graphml+xml")
+	assert.Equal(s.T(), 200, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "application/graphml+xml")
+	assert.Contains(s.T(), w.Body.String(), `<node id="s2">`)
+	assert.NotContains(s.T(), w.Body.String(), `<node id="s1">`)
+
+	w = s.request("GET", "/services/ledger/graph?depth=2&format=dot", "", "")
+	assert.Contains(s.T(), w.Body.String(), `s2 -> s1`)
+
+	w = s.request("DELETE", "/services/payments?force=true", "", "")
+	assert.Equal(s.T(), 204, w.Code)
+
+	w = s.request("GET", "/graph", "", "")
+	assert.NotContains(s.T(), w.Body.String(), "Payments")
+	assert.NotContains(s.T(), w.Body.String(), "s3 -> s2")
+
+	w = s.request("GET", "/graph?showDeleted=true", "", "")
+	assert.Contains(s.T(), w.Body.String(), `s2 [label="Payments\n0 versions", style=dashed];`)
+
+	w = s.request("GET", "/graph?format=svg", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+	w = s.request("GET", "/services/1/graph?depth=0", "", "")
+	assert.Equal(s.T(), 400, w.Code)
+}
+
+// request performs an HTTP request against the test router.
+func (s *HandlerTestSuite) request(method, path, contentType, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// acceptRequest performs a GET request with the given Accept header.
+func (s *HandlerTestSuite) acceptRequest(path, accept string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	req.Header.Set("Accept", accept)
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// adminRequest performs an HTTP request carrying the admin token.
+func (s *HandlerTestSuite) adminRequest(method, path, contentType, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set(constants.AdminTokenHeader, testAdminToken)
+	s.router.ServeHTTP(w, req)
+	return w
 }
 
 func TestHandlerSuite(t *testing.T) {
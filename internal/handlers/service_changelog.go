@@ -0,0 +1,163 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/changelog"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+)
+
+// UpdateReleaseNotes handles PUT /services/:id/versions/:version/notes endpoint.
+//
+// Replaces the Markdown release notes of a version. Empty notes remove
+// them.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Request Body:
+//   - release_notes (string): Markdown, at most 20000 characters
+//
+// Returns:
+//
+//	200: Version with the new notes
+//	400: Invalid service ID, version or malformed body
+//	404: Version not found for this service
+//	422: Notes are too long
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/1/versions/2.1.0/notes
+//	{"release_notes": "### Fixed\n\n- Retry declined payments once"}
+func (h *Handler) UpdateReleaseNotes(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var req ReleaseNotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if validationErr := validation.NewValidationError(
+		validation.ValidateReleaseNotes(req.ReleaseNotes),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	version.ReleaseNotes = req.ReleaseNotes
+	result := h.db.Model(version).UpdateColumn("release_notes", version.ReleaseNotes)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrNotesUpdateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// GetServiceChangelog handles GET /services/:id/changelog endpoint.
+//
+// Renders the release notes of every published version of a service,
+// newest first by semantic version precedence. Drafts are left out. The
+// format follows the Accept header: JSON by default, Markdown for
+// text/markdown and an HTML page for text/html, so browsers opening the
+// link get the page.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Returns:
+//
+//	200: ChangelogResponse, Markdown or HTML
+//	400: Invalid service ID
+//	404: Service not found
+//	500: Database or rendering error
+//
+// Example:
+//
+//	GET /services/payment-gateway/changelog
+//	Accept: text/markdown
+func (h *Handler) GetServiceChangelog(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	var versions []models.Version
+	err := h.db.WithContext(ctx).
+		Where("service_id = ? AND status <> ?", service.ID, models.VersionDraft).
+		Order(versionPrecedenceOrder(constants.DescSortOrder)).
+		Find(&versions).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	entries := make([]changelog.Entry, 0, len(versions))
+	for _, version := range versions {
+		entries = append(entries, changelog.Entry{
+			Version: version.Number,
+			Status:  string(version.Status),
+			Date:    version.CreatedAt,
+			Notes:   version.ReleaseNotes,
+		})
+	}
+
+	title := service.Name + " changelog"
+	switch c.NegotiateFormat(gin.MIMEJSON, constants.ContentTypeMarkdown, gin.MIMEHTML) {
+	case constants.ContentTypeMarkdown:
+		c.Data(http.StatusOK, constants.ContentTypeMarkdown+"; charset=utf-8", []byte(changelog.Markdown(title, entries)))
+	case gin.MIMEHTML:
+		page, err := changelog.HTML(title, entries)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrChangelogFailed,
+				Details: err.Error(),
+			})
+			return
+		}
+		c.Data(http.StatusOK, gin.MIMEHTML+"; charset=utf-8", []byte(page))
+	default:
+		c.JSON(http.StatusOK, ChangelogResponse{ServiceID: service.ID, Name: service.Name, Entries: entries})
+	}
+}
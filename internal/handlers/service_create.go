@@ -0,0 +1,182 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/slug"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// CreateService handles POST /services endpoint.
+//
+// Creates a new service from a JSON body. Name, slug and description are
+// validated before anything is written to the database.
+//
+// Request Body:
+//   - name (string): Service name, required, at most 255 characters
+//   - slug (string): Optional URL-safe identifier, generated from the name
+//     when omitted
+//   - description (string): Optional description, at most 2000 characters
+//   - owner_id (int): Optional ID of the owning team
+//   - lifecycle (string): Initial lifecycle stage, proposed (default),
+//     experimental or production; later stages are only reached through
+//     transitions
+//   - labels (object): Optional label keys and values, e.g. {"tier": "critical"}
+//   - attributes (object): Optional custom attributes, validated against the
+//     attribute schema
+//
+// Returns:
+//
+//	201: ServiceResponse of the created service, with a Location header
+//	400: Malformed JSON body
+//	409: Slug already used by another service
+//	422: Field validation failed, the owner team does not exist or the
+//	     attributes do not match the schema, see the fields list for details
+//	500: Database error
+//
+// Example:
+//
+//	POST /services
+//	{"name": "Billing Service", "description": "Generates invoices"}
+func (h *Handler) CreateService(c *gin.Context) {
+	var req CreateServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if req.Lifecycle == "" {
+		req.Lifecycle = string(models.LifecycleProposed)
+	}
+
+	if validationErr := validation.NewValidationError(
+		validation.ValidateServiceName(req.Name),
+		validation.ValidateSlug(req.Slug),
+		validation.ValidateServiceDescription(req.Description),
+		validation.ValidateInitialLifecycle(req.Lifecycle),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	if validationErr := validateLabels(req.Labels, nil); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	if !h.validateAttributes(c, req.Attributes) {
+		return
+	}
+
+	owner, ok := h.findOwner(c, req.OwnerID)
+	if !ok {
+		return
+	}
+
+	service := models.Service{
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        req.Slug,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		Lifecycle:   models.LifecycleStage(req.Lifecycle),
+		Labels:      labelRows(0, req.Labels),
+		Attributes:  req.Attributes,
+	}
+	if service.Slug == "" {
+		service.Slug = slug.Make(service.Name)
+	}
+
+	if !h.checkSlugAvailable(c, service.Slug, 0) {
+		return
+	}
+
+	// The owner is attached after the insert so that GORM does not try to
+	// upsert the team as an association; labels are inserted with the service
+	if result := h.db.Create(&service); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			writeSlugConflict(c, service.Slug)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceCreateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	service.Owner = owner
+	c.Header("Location", fmt.Sprintf("/services/%d", service.ID))
+	c.JSON(http.StatusCreated, service.ToResponse(0))
+}
+
+// findOwner loads the team referenced by a service's owner_id. A nil ID
+// means the service has no owner and returns a nil team. When the team does
+// not exist a 422 validation response is written and false is returned.
+func (h *Handler) findOwner(c *gin.Context, ownerID *uint) (*models.Team, bool) {
+	if ownerID == nil {
+		return nil, true
+	}
+
+	var team models.Team
+	result := h.db.Limit(1).Find(&team, *ownerID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return nil, false
+	}
+	if result.RowsAffected == 0 {
+		validationErr := validation.NewValidationError(
+			&constants.FieldError{Field: constants.OwnerID, Message: constants.ErrUnknownTeam},
+		)
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+	return &team, true
+}
+
+// checkSlugAvailable reports whether slug is unused by services other than
+// exceptID, writing a 409 or 500 response otherwise. Slugs of soft-deleted
+// services stay reserved so that restoring them cannot clash.
+func (h *Handler) checkSlugAvailable(c *gin.Context, slug string, exceptID uint) bool {
+	var count int64
+	err := h.db.Unscoped().Model(&models.Service{}).
+		Where("slug = ? AND id <> ?", slug, exceptID).
+		Count(&count).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+	if count > 0 {
+		writeSlugConflict(c, slug)
+		return false
+	}
+	return true
+}
+
+// writeSlugConflict writes the 409 response for a slug that is already taken.
+func writeSlugConflict(c *gin.Context, slug string) {
+	c.JSON(http.StatusConflict, &constants.ServiceError{
+		Status:  constants.StatusConflict,
+		Message: constants.ErrSlugExists,
+		Details: slug,
+	})
+}
@@ -2,11 +2,14 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"net/http"
 	"serviceCatalog/internal/constants"
 	"serviceCatalog/internal/models"
-	"serviceCatalog/internal/validation"
+	"serviceCatalog/internal/retention"
+	"time"
 )
 
 // DeleteService handles DELETE /services/:id endpoint.
@@ -15,35 +18,73 @@ import (
 // Uses GORM's soft delete mechanism to maintain data history.
 //
 // URL Parameters:
-//   - id (uint): Service ID to delete
+//   - id (string): Service ID or slug to delete
+//
+// Query Parameters:
+//   - purge (bool): Permanently delete the service and its versions,
+//     requires a valid X-Admin-Token header
+//   - force (bool): Delete the service even if it is not retired or other
+//     services depend on it
 //
 // Returns:
 //
+//	200: PurgeResult with the number of rows removed (purge only)
 //	204: Service successfully deleted
 //	400: Invalid service ID
+//	403: Purge requested without admin credentials
+//	404: Service to purge not found
+//	409: Service is not retired or has dependents, and force is not set
 //	500: Deletion failed
 //
 // Notes:
 //   - Service is soft-deleted by default
-//   - Associated versions are also soft-deleted via foreign key
+//   - Only retired services without live dependents are deleted unless
+//     force=true
+//   - Dependency edges are kept and come back with RestoreService
+//   - Associated live versions are soft-deleted in the same transaction
+//     and restored together with the service by RestoreService
 //
 // Example:
 //
 //	DELETE /services/1
+//	DELETE /services/1?purge=true
 func (h *Handler) DeleteService(c *gin.Context) {
-	serviceID, validationErr := validation.ValidateServiceID(c)
-	if validationErr != nil {
-		c.JSON(validationErr.Status, validationErr)
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
 		return
 	}
 
-	result := h.db.Delete(&models.Service{}, serviceID)
-	if result.Error != nil {
+	if c.Query(constants.Purge) == constants.True {
+		h.purgeService(c, serviceID)
+		return
+	}
+
+	if c.Query(constants.Force) != constants.True &&
+		(!h.checkRetired(c, serviceID) || !h.checkDependents(c, serviceID)) {
+		return
+	}
+
+	// Soft-delete the service and its live versions with the same timestamp
+	// so that RestoreService can tell which versions were deleted with it
+	deletedAt := time.Now().Truncate(time.Microsecond)
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Service{}).
+			Where("id = ?", serviceID).
+			UpdateColumn("deleted_at", deletedAt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&models.Version{}).
+			Where("service_id = ?", serviceID).
+			UpdateColumn("deleted_at", deletedAt).Error
+	})
+	if err != nil {
 
 		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
 			Status:  constants.StatusInternalServerError,
 			Message: constants.ErrServiceDeleteFailed,
-			Details: result.Error.Error(),
+			Details: err.Error(),
 		})
 
 		return
@@ -51,3 +92,53 @@ func (h *Handler) DeleteService(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// checkRetired reports whether a live service may be deleted because it is
+// retired, writing a 409 or 500 response otherwise. Services that do not
+// exist pass, so that deleting them stays a no-op.
+func (h *Handler) checkRetired(c *gin.Context, serviceID uint64) bool {
+	var service models.Service
+	result := h.db.Select("id", "lifecycle").Limit(1).Find(&service, serviceID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return false
+	}
+	if result.RowsAffected > 0 && service.Lifecycle != models.LifecycleRetired {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrServiceNotRetired,
+			Details: fmt.Sprintf("service is %s; retire it first or pass force=true", service.Lifecycle),
+		})
+		return false
+	}
+	return true
+}
+
+// purgeService permanently deletes a service and its versions. Only admins
+// may purge, and the service may be live or soft-deleted.
+func (h *Handler) purgeService(c *gin.Context, serviceID uint64) {
+	if !requireAdmin(c, "purging requires admin credentials") {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, true)
+	if !ok {
+		return
+	}
+
+	result, err := retention.PurgeService(h.db, service.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServicePurgeFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
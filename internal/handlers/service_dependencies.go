@@ -0,0 +1,376 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// ListServiceDependencies handles GET /services/:id/dependencies endpoint.
+//
+// Lists the services a service depends on. Edges to soft-deleted services
+// are left out.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - kind (string): Only edges of this kind, e.g. "sync-http"
+//
+// Returns:
+//
+//	200: DependenciesResponse, ordered by edge ID
+//	400: Invalid service ID or kind
+//	404: Service not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/payment-gateway/dependencies?kind=sync-http
+func (h *Handler) ListServiceDependencies(c *gin.Context) {
+	service, edges, ok := h.listEdges(c, "service_id")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, DependenciesResponse{ServiceID: service.ID, Dependencies: edges})
+}
+
+// ListServiceDependents handles GET /services/:id/dependents endpoint.
+//
+// Lists the services that depend on a service, i.e. the services affected
+// when it fails. Edges from soft-deleted services are left out.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - kind (string): Only edges of this kind, e.g. "database"
+//
+// Returns:
+//
+//	200: DependentsResponse, ordered by edge ID
+//	400: Invalid service ID or kind
+//	404: Service not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/authentication-service/dependents
+func (h *Handler) ListServiceDependents(c *gin.Context) {
+	service, edges, ok := h.listEdges(c, "depends_on_id")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, DependentsResponse{ServiceID: service.ID, Dependents: edges})
+}
+
+// CreateServiceDependency handles POST /services/:id/dependencies endpoint.
+//
+// Records that a service depends on another live service.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug of the depending service
+//
+// Request Body:
+//   - depends_on_id (int): ID of the service depended on, required
+//   - kind (string): One of sync-http, async-queue, database
+//   - criticality (string): Optional, one of low, medium, high
+//
+// Returns:
+//
+//	201: DependencyResponse, with a Location header
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	409: The service is deleted or the edge already exists
+//	422: Field validation failed, the target does not exist or is the
+//	     service itself
+//	500: Database error
+//
+// Example:
+//
+//	POST /services/payment-gateway/dependencies
+//	{"depends_on_id": 1, "kind": "sync-http", "criticality": "high"}
+func (h *Handler) CreateServiceDependency(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req DependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var targetErr *constants.FieldError
+	if req.DependsOnID == 0 {
+		targetErr = &constants.FieldError{Field: constants.DependsOnID, Message: constants.ErrFieldRequired}
+	} else if uint64(req.DependsOnID) == serviceID {
+		targetErr = &constants.FieldError{Field: constants.DependsOnID, Message: constants.ErrSelfDependency}
+	}
+	if validationErr := validation.NewValidationError(
+		targetErr,
+		validation.ValidateDependencyKind(req.Kind),
+		validation.ValidateCriticality(req.Criticality),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	service, ok := h.findLiveService(c, serviceID)
+	if !ok {
+		return
+	}
+
+	var target models.Service
+	result := h.db.Limit(1).Find(&target, req.DependsOnID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		validationErr := validation.NewValidationError(
+			&constants.FieldError{Field: constants.DependsOnID, Message: constants.ErrUnknownService},
+		)
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	edge := models.ServiceDependency{
+		ServiceID:   service.ID,
+		DependsOnID: target.ID,
+		Kind:        models.DependencyKind(req.Kind),
+		Criticality: models.Criticality(req.Criticality),
+	}
+	if result := h.db.Create(&edge); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			c.JSON(http.StatusConflict, &constants.ServiceError{
+				Status:  constants.StatusConflict,
+				Message: constants.ErrDependencyExists,
+				Details: fmt.Sprintf("%s -> %s (%s)", service.Slug, target.Slug, edge.Kind),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDependencyCreateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/services/%d/dependencies/%d", service.ID, edge.ID))
+	c.JSON(http.StatusCreated, dependencyResponse(edge, &target))
+}
+
+// DeleteServiceDependency handles DELETE /services/:id/dependencies/:dependencyId endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug of the depending service
+//   - dependencyId (int): Dependency ID
+//
+// Returns:
+//
+//	204: Dependency removed
+//	400: Invalid service or dependency ID
+//	404: Service or dependency not found for this service
+//	409: The service is deleted
+//	500: Database error
+func (h *Handler) DeleteServiceDependency(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	dependencyID, validationErr := validation.ValidateDependencyID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	if _, ok := h.findLiveService(c, serviceID); !ok {
+		return
+	}
+
+	result := h.db.Where("service_id = ?", serviceID).Delete(&models.ServiceDependency{}, dependencyID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDependencyDeleteFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrDependencyNotFound,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// findLiveService loads a service whose dependencies are changed. A
+// soft-deleted service is reported as a conflict rather than a missing
+// service, like publishing a version of it.
+func (h *Handler) findLiveService(c *gin.Context, serviceID uint64) (*models.Service, bool) {
+	service, ok := h.findService(c, serviceID, true)
+	if !ok {
+		return nil, false
+	}
+	if service.DeletedAt.Valid {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrServiceDeleted,
+			Details: "cannot change the dependencies of a deleted service",
+		})
+		return nil, false
+	}
+	return service, true
+}
+
+// listEdges loads the edges of a live service in one direction, column
+// being "service_id" for its dependencies or "depends_on_id" for its
+// dependents, together with the live services at their other end.
+func (h *Handler) listEdges(c *gin.Context, column string) (*models.Service, []DependencyResponse, bool) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	kind := c.Query(constants.Kind)
+	if kind != "" {
+		if fieldErr := validation.ValidateDependencyKind(kind); fieldErr != nil {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrBadRequest,
+				Details: fieldErr.Field + " " + fieldErr.Message,
+			})
+			return nil, nil, false
+		}
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return nil, nil, false
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	other := "depends_on_id"
+	if column == "depends_on_id" {
+		other = "service_id"
+	}
+
+	query := h.db.WithContext(ctx).
+		Where("service_dependencies."+column+" = ?", service.ID).
+		Where("EXISTS (SELECT 1 FROM services WHERE services.id = service_dependencies." + other + " AND services.deleted_at IS NULL)")
+	if kind != "" {
+		query = query.Where("service_dependencies.kind = ?", kind)
+	}
+
+	var edges []models.ServiceDependency
+	if err := query.Order("service_dependencies.id").Find(&edges).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDependenciesFetchFailed,
+			Details: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	otherIDs := make([]uint, 0, len(edges))
+	for _, edge := range edges {
+		if column == "service_id" {
+			otherIDs = append(otherIDs, edge.DependsOnID)
+		} else {
+			otherIDs = append(otherIDs, edge.ServiceID)
+		}
+	}
+
+	services := make(map[uint]*models.Service, len(otherIDs))
+	if len(otherIDs) > 0 {
+		var others []models.Service
+		if err := h.db.WithContext(ctx).Where("id IN ?", otherIDs).Find(&others).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrDependenciesFetchFailed,
+				Details: err.Error(),
+			})
+			return nil, nil, false
+		}
+		for i := range others {
+			services[others[i].ID] = &others[i]
+		}
+	}
+
+	responses := make([]DependencyResponse, 0, len(edges))
+	for i, edge := range edges {
+		if other, ok := services[otherIDs[i]]; ok {
+			responses = append(responses, dependencyResponse(edge, other))
+		}
+	}
+	return service, responses, true
+}
+
+// dependencyResponse describes an edge from the side of one service, with
+// other being the service at its other end.
+func dependencyResponse(edge models.ServiceDependency, other *models.Service) DependencyResponse {
+	return DependencyResponse{
+		ID:          edge.ID,
+		Service:     other.ToSummary(),
+		Kind:        edge.Kind,
+		Criticality: edge.Criticality,
+		CreatedAt:   edge.CreatedAt,
+	}
+}
+
+// checkDependents reports whether a service may be deleted because no live
+// service depends on it, writing a 409 response naming the dependents, or
+// a 500 response, otherwise.
+func (h *Handler) checkDependents(c *gin.Context, serviceID uint64) bool {
+	var dependents []string
+	err := h.db.Model(&models.Service{}).
+		Distinct("services.slug").
+		Joins("JOIN service_dependencies ON service_dependencies.service_id = services.id").
+		Where("service_dependencies.depends_on_id = ? AND services.id <> ?", serviceID, serviceID).
+		Order("services.slug").
+		Pluck("services.slug", &dependents).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDependenciesFetchFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+	if len(dependents) > 0 {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrServiceHasDependents,
+			Details: fmt.Sprintf("depended on by %s; remove the dependencies or pass force=true", strings.Join(dependents, ", ")),
+		})
+		return false
+	}
+	return true
+}
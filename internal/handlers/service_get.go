@@ -13,18 +13,18 @@ import (
 
 // GetService handles GET /services/:id endpoint.
 //
-// Retrieves a single service by ID with its version count.
+// Retrieves a single service by ID with its version count and links.
 // Supports including soft-deleted services via showDeleted parameter.
 //
 // URL Parameters:
-//   - id (uint): Service ID
+//   - id (string): Service ID or slug
 //
 // Query Parameters:
 //   - showDeleted (bool): Include soft-deleted service if true
 //
 // Returns:
 //
-//	200: ServiceResponse with service details and version count
+//	200: ServiceResponse with service details, version count and links
 //	400: Invalid service ID
 //	404: Service not found
 //	500: Database or server errors
@@ -33,22 +33,81 @@ import (
 //
 //	GET /services/1?showDeleted=true
 func (h *Handler) GetService(c *gin.Context) {
-	serviceID, validationErr := validation.ValidateServiceID(c)
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	// Add deleted filter if provided
+	showDeleted := c.Query(constants.ShowDeleted) == constants.True
+
+	service, ok := h.findService(c, serviceID, showDeleted)
+	if !ok {
+		return
+	}
+
+	response, err := h.serviceResponse(service, showDeleted)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// resolveServiceID reads the :id path parameter and returns the numeric ID
+// of the service it refers to, looking slugs up in the database. Slugs of
+// soft-deleted services are resolved too. When the parameter is invalid or
+// the slug is unknown the error response is written and false is returned.
+func (h *Handler) resolveServiceID(c *gin.Context) (uint64, bool) {
+	ref, validationErr := validation.ValidateServiceID(c)
 	if validationErr != nil {
 		c.JSON(validationErr.Status, validationErr)
-		return
+		return 0, false
+	}
+	if ref.Slug == "" {
+		return ref.ID, true
 	}
 
 	var service models.Service
-	var result *gorm.DB
+	result := h.db.Unscoped().Select("id").Where("slug = ?", ref.Slug).Limit(1).Find(&service)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return 0, false
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrServiceNotFound,
+			Details: ref.Slug,
+		})
+		return 0, false
+	}
+	return uint64(service.ID), true
+}
 
-	// Add deleted filter if provided
-	if showDeleted := c.Query(constants.ShowDeleted); showDeleted == constants.True {
-		result = h.db.Unscoped().First(&service, serviceID)
-	} else {
-		result = h.db.First(&service, serviceID)
+// findService loads a service by ID together with its owning team, labels
+// and links, writing a 404 or 500 response when it cannot be loaded.
+// Soft-deleted services are only returned when unscoped is set.
+func (h *Handler) findService(c *gin.Context, serviceID uint64, unscoped bool) (*models.Service, bool) {
+	var service models.Service
+	query := h.db
+	if unscoped {
+		query = query.Unscoped()
 	}
+	query = query.Preload("Owner").Preload("Labels").Preload("Links", func(db *gorm.DB) *gorm.DB {
+		return db.Order("type, id")
+	})
 
+	result := query.First(&service, serviceID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, &constants.ServiceError{
@@ -56,21 +115,41 @@ func (h *Handler) GetService(c *gin.Context) {
 				Message: constants.ErrServiceNotFound,
 				Details: result.Error.Error(),
 			})
-			return
+			return nil, false
 		}
 		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
 			Status:  constants.StatusInternalServerError,
 			Message: constants.ErrServiceFetchFailed,
 			Details: result.Error.Error(),
 		})
+		return nil, false
+	}
 
-		return
+	return &service, true
+}
+
+// serviceResponse converts a loaded service to its response with the
+// version count and whether the latest version has a specification.
+// Soft-deleted versions are only counted when includeDeleted is set.
+func (h *Handler) serviceResponse(service *models.Service, includeDeleted bool) (models.ServiceResponse, error) {
+	response := service.ToResponse(h.versionCount(service.ID, includeDeleted))
+	hasSpec, err := h.latestVersionHasSpec(service.ID)
+	if err != nil {
+		return models.ServiceResponse{}, err
 	}
+	response.LatestVersionHasSpec = hasSpec
+	return response, nil
+}
 
-	// Get version count
-	var versionCount int64
-	h.db.Model(&models.Version{}).Where("service_id = ?", service.ID).Count(&versionCount)
+// versionCount returns the number of versions associated with a service.
+// Soft-deleted versions are only counted when includeDeleted is set.
+func (h *Handler) versionCount(serviceID uint, includeDeleted bool) int {
+	query := h.db
+	if includeDeleted {
+		query = query.Unscoped()
+	}
 
-	response := service.ToResponse(int(versionCount))
-	c.JSON(http.StatusOK, response)
+	var versionCount int64
+	query.Model(&models.Version{}).Where("service_id = ?", serviceID).Count(&versionCount)
+	return int(versionCount)
 }
@@ -0,0 +1,286 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/graph"
+	"serviceCatalog/internal/models"
+	"strings"
+)
+
+// GetGraph handles GET /graph endpoint.
+//
+// Exports the dependency graph of the catalog, or of the services matching
+// the filters, as Graphviz DOT, a Mermaid flowchart or GraphML. Nodes are
+// annotated with the service name and version count; only dependencies
+// between two exported services are included.
+//
+// Query Parameters:
+//   - format (string): "dot", "mermaid" or "graphml"; when omitted the
+//     Accept header picks text/vnd.graphviz, text/vnd.mermaid or
+//     application/graphml+xml, defaulting to DOT
+//   - showDeleted (bool): Include soft-deleted services, drawn dashed
+//   - selector, owner and the other filters of GET /services
+//
+// Returns:
+//
+//	200: The graph in the requested format
+//	400: Invalid format or filter
+//	500: Database or rendering error
+//
+// Example:
+//
+//	GET /graph?format=mermaid&selector=domain%3Dpayments
+func (h *Handler) GetGraph(c *gin.Context) {
+	var params QueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	format, ok := negotiateGraphFormat(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	query := h.db.WithContext(ctx).Model(&models.Service{})
+	if params.ShowDeleted == constants.True {
+		query = query.Unscoped()
+	}
+	query, ok = filterServices(c, params, query)
+	if !ok {
+		return
+	}
+
+	var services []serviceWithVersion
+	if err := withVersionCount(query, params).Order("services.id").Find(&services).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServicesFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ids := make([]uint, 0, len(services))
+	for _, service := range services {
+		ids = append(ids, service.ID)
+	}
+
+	var edges []models.ServiceDependency
+	if len(ids) > 0 {
+		err := h.db.WithContext(ctx).
+			Where("service_id IN ? AND depends_on_id IN ?", ids, ids).
+			Order("id").
+			Find(&edges).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrDependenciesFetchFailed,
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	writeGraph(c, format, services, edges)
+}
+
+// GetServiceGraph handles GET /services/:id/graph endpoint.
+//
+// Exports the neighbourhood of a service: the service together with the
+// services reachable from it over dependency edges in either direction
+// within depth hops. Formats and node annotations are those of GetGraph.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - depth (int): Maximum number of hops (default: 1, max: 10)
+//   - format (string): "dot", "mermaid" or "graphml", see GetGraph
+//   - showDeleted (bool): Include soft-deleted services, drawn dashed
+//
+// Returns:
+//
+//	200: The graph in the requested format
+//	400: Invalid service ID, depth or format
+//	404: Service not found
+//	500: Database or rendering error
+//
+// Example:
+//
+//	GET /services/payment-gateway/graph?depth=2
+//	Accept: application/graphml+xml
+func (h *Handler) GetServiceGraph(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var params GraphQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	format, ok := negotiateGraphFormat(c)
+	if !ok {
+		return
+	}
+
+	showDeleted := params.ShowDeleted == constants.True
+	service, ok := h.findService(c, serviceID, showDeleted)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	edges, ids, err := h.neighbourhood(ctx, service.ID, params.Depth, showDeleted)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDependenciesFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	query := h.db.WithContext(ctx).Model(&models.Service{})
+	if showDeleted {
+		query = query.Unscoped()
+	}
+	var services []serviceWithVersion
+	err = withVersionCount(query.Where("services.id IN ?", ids), params.QueryParams).Order("services.id").Find(&services).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServicesFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	writeGraph(c, format, services, edges)
+}
+
+// neighbourhood collects the dependency edges within depth hops of a
+// service, following them in both directions one level per query, and the
+// IDs of the services they connect. Edges touching soft-deleted services
+// are skipped unless showDeleted is set.
+func (h *Handler) neighbourhood(ctx context.Context, serviceID uint, depth int, showDeleted bool) ([]models.ServiceDependency, []uint, error) {
+	ids := []uint{serviceID}
+	seen := map[uint]bool{serviceID: true}
+	seenEdges := map[uint]bool{}
+	var edges []models.ServiceDependency
+
+	frontier := ids
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		query := h.db.WithContext(ctx).Where("service_id IN ? OR depends_on_id IN ?", frontier, frontier)
+		if !showDeleted {
+			live := h.db.Model(&models.Service{}).Select("id")
+			query = query.Where("service_id IN (?) AND depends_on_id IN (?)", live, live)
+		}
+
+		var level []models.ServiceDependency
+		if err := query.Order("id").Find(&level).Error; err != nil {
+			return nil, nil, err
+		}
+
+		frontier = nil
+		for _, edge := range level {
+			if seenEdges[edge.ID] {
+				continue
+			}
+			seenEdges[edge.ID] = true
+			edges = append(edges, edge)
+			for _, id := range []uint{edge.ServiceID, edge.DependsOnID} {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+					frontier = append(frontier, id)
+				}
+			}
+		}
+	}
+	return edges, ids, nil
+}
+
+// negotiateGraphFormat picks the output format from the format parameter
+// or else the Accept header, defaulting to DOT. An unknown format
+// parameter writes a 400 response and returns false.
+func negotiateGraphFormat(c *gin.Context) (graph.Format, bool) {
+	names := make([]string, len(graph.Formats))
+	contentTypes := make([]string, len(graph.Formats))
+	for i, format := range graph.Formats {
+		names[i] = string(format)
+		contentTypes[i] = format.ContentType()
+	}
+
+	if name := c.Query(constants.Format); name != "" {
+		format, ok := graph.ParseFormat(name)
+		if !ok {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrBadRequest,
+				Details: constants.Format + " " + fmt.Sprintf(constants.ErrFieldNotOneOf, strings.Join(names, ", ")),
+			})
+			return "", false
+		}
+		return format, true
+	}
+
+	if format, ok := graph.FormatForContentType(c.NegotiateFormat(contentTypes...)); ok {
+		return format, true
+	}
+	return graph.FormatDOT, true
+}
+
+// writeGraph renders services and the edges between them in format.
+func writeGraph(c *gin.Context, format graph.Format, services []serviceWithVersion, edges []models.ServiceDependency) {
+	var g graph.Graph
+	for _, service := range services {
+		g.Nodes = append(g.Nodes, graph.Node{
+			ID:       service.ID,
+			Name:     service.Name,
+			Slug:     service.Slug,
+			Versions: int(service.VersionCount),
+			Deleted:  service.DeletedAt.Valid,
+		})
+	}
+	for _, edge := range edges {
+		g.Edges = append(g.Edges, graph.Edge{
+			From:        edge.ServiceID,
+			To:          edge.DependsOnID,
+			Kind:        string(edge.Kind),
+			Criticality: string(edge.Criticality),
+		})
+	}
+
+	output, err := g.Render(format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrGraphRenderFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+	c.Data(http.StatusOK, format.ContentType()+"; charset=utf-8", []byte(output))
+}
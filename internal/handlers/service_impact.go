@@ -0,0 +1,180 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"strconv"
+	"strings"
+)
+
+// impactQuery walks the dependency edges backwards from a service with a
+// recursive CTE, yielding one row per path to a live dependent up to a
+// maximum depth. Edges of different kinds between the same two services
+// count once. A path reaching a service it already contains is marked as
+// a cycle and not extended, so cyclic graphs terminate.
+const impactQuery = `
+WITH RECURSIVE edges AS (
+	SELECT DISTINCT d.service_id, d.depends_on_id
+	FROM service_dependencies d
+	JOIN services s ON s.id = d.service_id AND s.deleted_at IS NULL
+), impact(service_id, depth, path, cycle) AS (
+	SELECT e.service_id, 1, ARRAY[e.depends_on_id, e.service_id]::bigint[], e.service_id = e.depends_on_id
+	FROM edges e
+	WHERE e.depends_on_id = ?
+	UNION ALL
+	SELECT e.service_id, i.depth + 1, i.path || e.service_id::bigint, e.service_id = ANY(i.path)
+	FROM impact i
+	JOIN edges e ON e.depends_on_id = i.service_id
+	WHERE NOT i.cycle AND i.depth < ?
+)
+SELECT service_id, depth, array_to_string(path, ',') AS path, cycle
+FROM impact
+ORDER BY depth, path`
+
+// impactRow is a row of impactQuery; Path holds comma separated service IDs
+// starting at the analyzed service.
+type impactRow struct {
+	ServiceID uint
+	Depth     int
+	Path      string
+	Cycle     bool
+}
+
+// GetServiceImpact handles GET /services/:id/impact endpoint.
+//
+// Answers "if this service goes down, what else breaks?" by following
+// dependency edges transitively to every live service that depends on it,
+// directly or through others. Each affected service is reported once, with
+// its hop distance and the shortest path from the analyzed service.
+// Dependency cycles are detected and listed instead of being followed.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - depth (int): Maximum number of hops (default: 5, max: 10)
+//
+// Returns:
+//
+//	200: ImpactResponse, affected services ordered by distance
+//	400: Invalid service ID or depth
+//	404: Service not found
+//	500: Database error, including queries exceeding the request timeout
+//
+// Example:
+//
+//	GET /services/authentication-service/impact?depth=3
+func (h *Handler) GetServiceImpact(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var params ImpactQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	// Setup query timeout using context deadline or default 5s
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	var rows []impactRow
+	if err := h.db.WithContext(ctx).Raw(impactQuery, service.ID, params.Depth).Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrImpactFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	// Rows are ordered by depth, so the first path to a service is a
+	// shortest one
+	var affected []impactRow
+	var cycles []impactRow
+	seen := map[uint]bool{}
+	seenCycles := map[string]bool{}
+	ids := []uint{service.ID}
+	for _, row := range rows {
+		if row.Cycle {
+			if !seenCycles[row.Path] {
+				seenCycles[row.Path] = true
+				cycles = append(cycles, row)
+			}
+			continue
+		}
+		if !seen[row.ServiceID] {
+			seen[row.ServiceID] = true
+			affected = append(affected, row)
+			ids = append(ids, row.ServiceID)
+		}
+	}
+
+	var services []models.Service
+	if err := h.db.WithContext(ctx).Where("id IN ?", ids).Find(&services).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrImpactFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+	byID := make(map[uint]*models.Service, len(services))
+	for i := range services {
+		byID[services[i].ID] = &services[i]
+	}
+
+	response := ImpactResponse{
+		ServiceID: service.ID,
+		Depth:     params.Depth,
+		Affected:  make([]ImpactedService, 0, len(affected)),
+		Cycles:    make([][]string, 0, len(cycles)),
+	}
+	for _, row := range affected {
+		// The service was deleted after the traversal ran
+		affectedService, ok := byID[row.ServiceID]
+		if !ok {
+			continue
+		}
+		response.Affected = append(response.Affected, ImpactedService{
+			Service:  affectedService.ToSummary(),
+			Distance: row.Depth,
+			Path:     pathSlugs(row.Path, byID),
+		})
+	}
+	for _, row := range cycles {
+		response.Cycles = append(response.Cycles, pathSlugs(row.Path, byID))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// pathSlugs converts a comma separated path of service IDs into the slugs
+// of the services.
+func pathSlugs(path string, services map[uint]*models.Service) []string {
+	ids := strings.Split(path, ",")
+	slugs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parsed, _ := strconv.ParseUint(id, 10, 64)
+		if service, ok := services[uint(parsed)]; ok {
+			slugs = append(slugs, service.Slug)
+		} else {
+			slugs = append(slugs, id)
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,297 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/labels"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"sort"
+	"strings"
+)
+
+// GetServiceLabels handles GET /services/:id/labels endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Returns:
+//
+//	200: LabelsResponse with the labels of the service
+//	400: Invalid service ID
+//	404: Service not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/payment-gateway/labels
+func (h *Handler) GetServiceLabels(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, LabelsResponse{ServiceID: service.ID, Labels: models.LabelMap(service.Labels)})
+}
+
+// ReplaceServiceLabels handles PUT /services/:id/labels endpoint.
+//
+// Replaces all labels of a service with the labels in the body; labels
+// missing from the body are removed.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body: object of label keys to values
+//
+// Returns:
+//
+//	200: LabelsResponse with the new labels
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	422: Invalid label key or value
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/1/labels
+//	{"tier": "critical", "language": "go"}
+func (h *Handler) ReplaceServiceLabels(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req map[string]string
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+	if req == nil {
+		req = map[string]string{}
+	}
+
+	if validationErr := validateLabels(req, nil); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_id = ?", service.ID).Delete(&models.ServiceLabel{}).Error; err != nil {
+			return err
+		}
+		return upsertLabels(tx, service.ID, req)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLabelUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LabelsResponse{ServiceID: service.ID, Labels: req})
+}
+
+// PatchServiceLabels handles PATCH /services/:id/labels endpoint.
+//
+// Sets and removes individual labels with merge patch semantics: a string
+// value sets the label, null removes it and labels not mentioned are kept.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body: object of label keys to values or null
+//
+// Returns:
+//
+//	200: LabelsResponse with the resulting labels
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	422: Invalid label key or value
+//	500: Database error
+//
+// Example:
+//
+//	PATCH /services/1/labels
+//	{"tier": "critical", "legacy": null}
+func (h *Handler) PatchServiceLabels(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req map[string]*string
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	set := make(map[string]string, len(req))
+	var remove []string
+	for key, value := range req {
+		if value == nil {
+			remove = append(remove, key)
+		} else {
+			set[key] = *value
+		}
+	}
+	if validationErr := validateLabels(set, remove); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	var result []models.ServiceLabel
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			err := tx.Where("service_id = ? AND key IN ?", service.ID, remove).Delete(&models.ServiceLabel{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := upsertLabels(tx, service.ID, set); err != nil {
+			return err
+		}
+		return tx.Where("service_id = ?", service.ID).Find(&result).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLabelUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LabelsResponse{ServiceID: service.ID, Labels: models.LabelMap(result)})
+}
+
+// validateLabels checks the keys and values of labels to set and the keys
+// of labels to remove, reporting each invalid label as a field error named
+// "labels.<key>".
+func validateLabels(set map[string]string, remove []string) *constants.ServiceError {
+	keys := append([]string(nil), remove...)
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fieldErrors []*constants.FieldError
+	for _, key := range keys {
+		err := labels.ValidateKey(key)
+		if value, ok := set[key]; ok && err == nil {
+			err = labels.ValidateValue(value)
+		}
+		if err != nil {
+			fieldErrors = append(fieldErrors, &constants.FieldError{
+				Field:   constants.Labels + "." + key,
+				Message: err.Error(),
+			})
+		}
+	}
+	return validation.NewValidationError(fieldErrors...)
+}
+
+// labelRows converts a key/value map into label rows of a service, ordered by key.
+func labelRows(serviceID uint, values map[string]string) []models.ServiceLabel {
+	rows := make([]models.ServiceLabel, 0, len(values))
+	for key, value := range values {
+		rows = append(rows, models.ServiceLabel{ServiceID: serviceID, Key: key, Value: value})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	return rows
+}
+
+// upsertLabels inserts labels of a service, overwriting the value of keys
+// that already exist.
+func upsertLabels(tx *gorm.DB, serviceID uint, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	rows := labelRows(serviceID, values)
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "service_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value"}),
+	}).Create(&rows).Error
+}
+
+// loadLabels loads the labels of several services in a single query,
+// grouped by service ID.
+func (h *Handler) loadLabels(ctx context.Context, serviceIDs []uint) (map[uint][]models.ServiceLabel, error) {
+	byService := make(map[uint][]models.ServiceLabel, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return byService, nil
+	}
+
+	var rows []models.ServiceLabel
+	if err := h.db.WithContext(ctx).Where("service_id IN ?", serviceIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		byService[row.ServiceID] = append(byService[row.ServiceID], row)
+	}
+	return byService, nil
+}
+
+// labelSelectorCondition compiles a label selector into a parameterized
+// SQL condition on the services table. Every requirement becomes an
+// EXISTS or NOT EXISTS subquery on service_labels; keys and values are
+// only ever passed as arguments.
+func labelSelectorCondition(selector labels.Selector) (string, []interface{}) {
+	const label = "SELECT 1 FROM service_labels WHERE service_labels.service_id = services.id AND service_labels.key = ?"
+
+	conditions := make([]string, 0, len(selector))
+	var args []interface{}
+	for _, requirement := range selector {
+		switch requirement.Operator {
+		case labels.OpEquals:
+			conditions = append(conditions, "EXISTS ("+label+" AND service_labels.value = ?)")
+			args = append(args, requirement.Key, requirement.Values[0])
+		case labels.OpNotEquals:
+			conditions = append(conditions, "NOT EXISTS ("+label+" AND service_labels.value = ?)")
+			args = append(args, requirement.Key, requirement.Values[0])
+		case labels.OpIn:
+			conditions = append(conditions, "EXISTS ("+label+" AND service_labels.value IN ?)")
+			args = append(args, requirement.Key, requirement.Values)
+		case labels.OpNotIn:
+			conditions = append(conditions, "NOT EXISTS ("+label+" AND service_labels.value IN ?)")
+			args = append(args, requirement.Key, requirement.Values)
+		case labels.OpExists:
+			conditions = append(conditions, "EXISTS ("+label+")")
+			args = append(args, requirement.Key)
+		case labels.OpDoesNotExist:
+			conditions = append(conditions, "NOT EXISTS ("+label+")")
+			args = append(args, requirement.Key)
+		}
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", args
+}
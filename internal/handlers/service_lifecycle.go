@@ -0,0 +1,211 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// errStageChanged aborts a transition whose service left the expected
+// stage while the transition was being written.
+var errStageChanged = errors.New("lifecycle stage changed concurrently")
+
+// GetServiceLifecycle handles GET /services/:id/lifecycle endpoint.
+//
+// Returns the current lifecycle stage of a service, the stages it may
+// move to next and its transition history, newest first.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Returns:
+//
+//	200: LifecycleResponse
+//	400: Invalid service ID
+//	404: Service not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/payment-gateway/lifecycle
+func (h *Handler) GetServiceLifecycle(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	h.writeLifecycle(c, service)
+}
+
+// TransitionServiceLifecycle handles POST /services/:id/lifecycle endpoint.
+//
+// Moves a service to another lifecycle stage and records who made the
+// change and why. Allowed transitions are:
+//
+//	proposed     -> experimental, production, retired
+//	experimental -> production, deprecated, retired
+//	production   -> deprecated
+//	deprecated   -> production, retired
+//	retired      -> (none)
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body:
+//   - stage (string): Target stage, required
+//   - actor (string): Person or system making the change, required
+//   - reason (string): Optional explanation, at most 2000 characters
+//
+// Returns:
+//
+//	200: LifecycleResponse after the transition
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	409: The transition is not allowed from the current stage
+//	422: Field validation failed
+//	500: Database error
+//
+// Example:
+//
+//	POST /services/1/lifecycle
+//	{"stage": "deprecated", "actor": "jane@example.com", "reason": "Replaced by auth-v2"}
+func (h *Handler) TransitionServiceLifecycle(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req LifecycleTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if validationErr := validation.NewValidationError(
+		validation.ValidateLifecycleStage(constants.Stage, req.Stage),
+		validation.ValidateActor(req.Actor),
+		validation.ValidateReason(req.Reason),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	from, to := service.Lifecycle, models.LifecycleStage(req.Stage)
+	if !from.CanTransitionTo(to) {
+		writeIllegalTransition(c, from, to)
+		return
+	}
+
+	transition := models.LifecycleTransition{
+		ServiceID: service.ID,
+		From:      from,
+		To:        to,
+		Actor:     strings.TrimSpace(req.Actor),
+		Reason:    req.Reason,
+	}
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		// Only move the service if it is still in the stage that was checked
+		result := tx.Model(&models.Service{}).
+			Where("id = ? AND lifecycle = ?", service.ID, from).
+			UpdateColumn("lifecycle", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errStageChanged
+		}
+		return tx.Create(&transition).Error
+	})
+	if err != nil {
+		if errors.Is(err, errStageChanged) {
+			c.JSON(http.StatusConflict, &constants.ServiceError{
+				Status:  constants.StatusConflict,
+				Message: constants.ErrIllegalTransition,
+				Details: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLifecycleUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	service.Lifecycle = to
+	h.writeLifecycle(c, service)
+}
+
+// writeLifecycle writes the LifecycleResponse of a service, loading its
+// transition history.
+func (h *Handler) writeLifecycle(c *gin.Context, service *models.Service) {
+	history := []models.LifecycleTransition{}
+	err := h.db.Where("service_id = ?", service.ID).Order("created_at DESC, id DESC").Find(&history).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLifecycleFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LifecycleResponse{
+		ServiceID: service.ID,
+		Stage:     service.Lifecycle,
+		Next:      service.Lifecycle.Next(),
+		History:   history,
+	})
+}
+
+// writeIllegalTransition writes the 409 response for a transition the
+// lifecycle state machine does not allow.
+func writeIllegalTransition(c *gin.Context, from, to models.LifecycleStage) {
+	c.JSON(http.StatusConflict, &constants.ServiceError{
+		Status:  constants.StatusConflict,
+		Message: constants.ErrIllegalTransition,
+		Details: fmt.Sprintf("cannot move from %s to %s", from, to),
+	})
+}
+
+// parseLifecycleFilter splits a comma separated list of lifecycle stages,
+// such as "production,deprecated", writing a 400 response and returning
+// false when a stage is unknown.
+func parseLifecycleFilter(c *gin.Context, value string) ([]string, bool) {
+	var stages []string
+	for _, stage := range strings.Split(value, ",") {
+		stage = strings.TrimSpace(stage)
+		if !models.LifecycleStage(stage).Valid() {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrBadRequest,
+				Details: constants.Lifecycle + " " + fmt.Sprintf(constants.ErrFieldNotOneOf, validation.LifecycleStageNames()),
+			})
+			return nil, false
+		}
+		stages = append(stages, stage)
+	}
+	return stages, true
+}
@@ -0,0 +1,291 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// ListServiceLinks handles GET /services/:id/links endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - type (string): Only links of this type
+//
+// Returns:
+//
+//	200: LinksResponse with the links of the service, ordered by type and ID
+//	400: Invalid service ID or link type
+//	404: Service not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/payment-gateway/links?type=runbook
+func (h *Handler) ListServiceLinks(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	linkType := c.Query(constants.Type)
+	if linkType != "" && !models.LinkType(linkType).Valid() {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: constants.Type + " " + fmt.Sprintf(constants.ErrFieldNotOneOf, validation.LinkTypeNames()),
+		})
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	links := make([]models.ServiceLink, 0, len(service.Links))
+	for _, link := range service.Links {
+		if linkType == "" || string(link.Type) == linkType {
+			links = append(links, link)
+		}
+	}
+
+	c.JSON(http.StatusOK, LinksResponse{ServiceID: service.ID, Links: links})
+}
+
+// CreateServiceLink handles POST /services/:id/links endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body:
+//   - type (string): One of repository, runbook, dashboard, docs
+//   - url (string): Absolute http or https URL
+//   - title (string): Optional display title
+//
+// Returns:
+//
+//	201: The created ServiceLink, with a Location header
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	409: The service already has this URL for the type
+//	422: Field validation failed
+//	500: Database error
+//
+// Example:
+//
+//	POST /services/1/links
+//	{"type": "runbook", "url": "https://wiki.example.com/payments/oncall"}
+func (h *Handler) CreateServiceLink(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindLinkRequest(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	link := models.ServiceLink{
+		ServiceID: service.ID,
+		Type:      models.LinkType(req.Type),
+		URL:       req.URL,
+		Title:     strings.TrimSpace(req.Title),
+	}
+	if result := h.db.Create(&link); result.Error != nil {
+		writeLinkError(c, result.Error, constants.ErrLinkCreateFailed, link)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/services/%d/links/%d", service.ID, link.ID))
+	c.JSON(http.StatusCreated, link)
+}
+
+// GetServiceLink handles GET /services/:id/links/:linkId endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - linkId (int): Link ID
+//
+// Returns:
+//
+//	200: ServiceLink
+//	400: Invalid service or link ID
+//	404: Link not found for this service
+//	500: Database error
+func (h *Handler) GetServiceLink(c *gin.Context) {
+	link, ok := h.resolveLink(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// UpdateServiceLink handles PUT /services/:id/links/:linkId endpoint.
+//
+// Replaces the type, URL and title of a link.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - linkId (int): Link ID
+//
+// Request Body: same as CreateServiceLink
+//
+// Returns:
+//
+//	200: The updated ServiceLink
+//	400: Invalid service or link ID, or malformed body
+//	404: Link not found for this service
+//	409: The service already has this URL for the type
+//	422: Field validation failed
+//	500: Database error
+func (h *Handler) UpdateServiceLink(c *gin.Context) {
+	link, ok := h.resolveLink(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindLinkRequest(c)
+	if !ok {
+		return
+	}
+
+	link.Type = models.LinkType(req.Type)
+	link.URL = req.URL
+	link.Title = strings.TrimSpace(req.Title)
+	if result := h.db.Save(link); result.Error != nil {
+		writeLinkError(c, result.Error, constants.ErrLinkUpdateFailed, *link)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// DeleteServiceLink handles DELETE /services/:id/links/:linkId endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - linkId (int): Link ID
+//
+// Returns:
+//
+//	204: Link deleted
+//	400: Invalid service or link ID
+//	404: Link not found for this service
+//	500: Database error
+func (h *Handler) DeleteServiceLink(c *gin.Context) {
+	link, ok := h.resolveLink(c)
+	if !ok {
+		return
+	}
+
+	if result := h.db.Delete(link); result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLinkDeleteFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// resolveLink reads the :id and :linkId path parameters and loads the link
+// of a live service, writing the error response and returning false when
+// either cannot be found.
+func (h *Handler) resolveLink(c *gin.Context) (*models.ServiceLink, bool) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return nil, false
+	}
+
+	linkID, validationErr := validation.ValidateLinkID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+
+	if _, ok := h.findService(c, serviceID, false); !ok {
+		return nil, false
+	}
+
+	var link models.ServiceLink
+	result := h.db.Where("service_id = ?", serviceID).First(&link, linkID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, &constants.ServiceError{
+				Status:  constants.StatusNotFound,
+				Message: constants.ErrLinkNotFound,
+				Details: result.Error.Error(),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLinksFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return nil, false
+	}
+
+	return &link, true
+}
+
+// bindLinkRequest binds and validates a link request body, writing a 400
+// or 422 response and returning false when it is invalid.
+func bindLinkRequest(c *gin.Context) (LinkRequest, bool) {
+	var req LinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return req, false
+	}
+
+	if validationErr := validation.NewValidationError(
+		validation.ValidateLinkType(req.Type),
+		validation.ValidateLinkURL(req.URL),
+		validation.ValidateLinkTitle(req.Title),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return req, false
+	}
+	return req, true
+}
+
+// writeLinkError writes the response for a failed link write, a 409 when
+// the link duplicates another one of the service.
+func writeLinkError(c *gin.Context, err error, message string, link models.ServiceLink) {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrLinkExists,
+			Details: string(link.Type) + " " + link.URL,
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+		Status:  constants.StatusInternalServerError,
+		Message: message,
+		Details: err.Error(),
+	})
+}
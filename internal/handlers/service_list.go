@@ -5,11 +5,16 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"net/http"
+	"serviceCatalog/internal/attributes"
 	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/labels"
 	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strconv"
 	"time"
 )
 
@@ -23,7 +28,8 @@ import (
 //     computed via JOIN in the same query
 type serviceWithVersion struct {
 	models.Service
-	VersionCount int64 `gorm:"column:version_count"`
+	VersionCount         int64 `gorm:"column:version_count"`
+	LatestVersionHasSpec bool  `gorm:"column:latest_version_has_spec"`
 }
 
 // ListServices returns a paginated list of services.
@@ -42,6 +48,13 @@ type serviceWithVersion struct {
 //   - sortBy (string): Column to sort by ("id", "name", "description")
 //   - sortDir (string): Sort direction ("asc", "desc")
 //   - showDeleted (bool): Whether to include soft-deleted records
+//   - owner (string): Only services owned by this team, given by ID or name
+//   - selector (string): Label selector, e.g. "tier=critical,domain in (payments,billing)"
+//   - attr.<path> (string): Attribute filter, e.g. "attr.costCenter=42" or
+//     "attr.pagerduty.id=P123"; repeat a parameter to match any of its values
+//   - missingLink (string): Only services without a link of this type, e.g. "runbook"
+//   - lifecycle (string): Only services in these stages, e.g. "production,deprecated"
+//   - releasedOnly (bool): Count only released versions
 //
 // Returns:
 //   200 OK: ListServicesResponse{
@@ -79,18 +92,66 @@ func (h *Handler) ListServices(c *gin.Context) {
 	}
 
 	// Fetch services with optimized version counting
-	services, totalCount := h.fetchListServices(c, params, query)
+	services, totalCount, ok := h.fetchListServices(c, params, query)
+	if !ok {
+		return
+	}
+
+	h.writeServiceList(c, params, services, totalCount)
+}
+
+// writeServiceList writes a page of services as a ListServicesResponse,
+// loading the owning teams and the labels of all services in one query each.
+func (h *Handler) writeServiceList(c *gin.Context, params QueryParams, services []serviceWithVersion, totalCount int64) {
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	serviceIDs := make([]uint, 0, len(services))
+	ownerIDs := make([]uint, 0, len(services))
+	for _, service := range services {
+		serviceIDs = append(serviceIDs, service.ID)
+		if service.OwnerID != nil {
+			ownerIDs = append(ownerIDs, *service.OwnerID)
+		}
+	}
+
+	owners := make(map[uint]*models.Team, len(ownerIDs))
+	if len(ownerIDs) > 0 {
+		var teams []models.Team
+		if err := h.db.WithContext(ctx).Where("id IN ?", ownerIDs).Find(&teams).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrOwnerFetchFailed,
+				Details: err.Error(),
+			})
+			return
+		}
+		for i := range teams {
+			owners[teams[i].ID] = &teams[i]
+		}
+	}
+
+	serviceLabels, err := h.loadLabels(ctx, serviceIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrLabelsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
 
 	// Transform database models to response DTOs
 	// Pre-allocate slice capacity for better performance
 	serviceResponses := make([]models.ServiceResponse, 0, len(services))
 	for _, service := range services {
-		serviceResponses = append(serviceResponses, models.ServiceResponse{
-			ID:          service.ID,
-			Name:        service.Name,
-			Description: service.Description,
-			Versions:    int(service.VersionCount),
-		})
+		if service.OwnerID != nil {
+			service.Owner = owners[*service.OwnerID]
+		}
+		service.Labels = serviceLabels[service.ID]
+		response := service.ToResponse(int(service.VersionCount))
+		response.LatestVersionHasSpec = service.LatestVersionHasSpec
+		serviceResponses = append(serviceResponses, response)
 	}
 
 	// Construct final response with pagination metadata
@@ -109,6 +170,11 @@ func (h *Handler) ListServices(c *gin.Context) {
 // This function handles complex query building including:
 //   - Timeout management via context
 //   - Search filtering with ILIKE for PostgreSQL
+//   - Owner filtering by team ID or team name
+//   - Label selector filtering, compiled to parameterized subqueries
+//   - Attribute filtering with the JSONB #>> operator
+//   - Filtering on services missing a link type
+//   - Lifecycle stage filtering
 //   - Dynamic column sorting with validation
 //   - Efficient pagination with total count
 //   - Version counting via LEFT JOIN
@@ -121,31 +187,24 @@ func (h *Handler) ListServices(c *gin.Context) {
 // Returns:
 //   - []ServiceWithVersion: Slice of services with their version counts
 //   - int64: Total count of matching records before pagination
-//   - error: Any errors encountered during query execution
+//   - bool: False when a query failed; the error response has been written
 //
 // Query Performance:
 //   - Uses single query with JOIN for version counting
 //   - Implements pagination before JOIN for better performance
 //   - Uses indexed columns for sorting and filtering
 //   - Handles NULL cases with COALESCE
-func (h *Handler) fetchListServices(c *gin.Context, params QueryParams, query *gorm.DB) ([]serviceWithVersion, int64) {
+func (h *Handler) fetchListServices(c *gin.Context, params QueryParams, query *gorm.DB) ([]serviceWithVersion, int64, bool) {
 
 	// Setup query timeout using context deadline or default 5s
-	timeoutDuration := 5 * time.Second
-	if deadline, ok := c.Request.Context().Deadline(); ok {
-		timeoutDuration = time.Until(deadline)
-	}
-
-	ctx, cancel := context.WithTimeout(c.Request.Context(), timeoutDuration)
+	ctx, cancel := queryContext(c)
 	defer cancel()
 
 	query = query.WithContext(ctx)
 
-	// Apply case-insensitive search on name and description
-	// ILIKE is PostgreSQL specific, provides better performance than LOWER()
-	if params.Search != "" {
-		query = query.Where("services.name ILIKE ? OR services.description ILIKE ?",
-			"%"+params.Search+"%", "%"+params.Search+"%")
+	query, ok := filterServices(c, params, query)
+	if !ok {
+		return nil, 0, false
 	}
 
 	// Determine sort column with input validation
@@ -178,7 +237,7 @@ func (h *Handler) fetchListServices(c *gin.Context, params QueryParams, query *g
 			Details: err.Error(),
 		})
 
-		return nil, 0
+		return nil, 0, false
 	}
 
 	// Calculate pagination offset
@@ -189,13 +248,12 @@ func (h *Handler) fetchListServices(c *gin.Context, params QueryParams, query *g
 	// Build and execute final query with versions count in a single call
 	// Execute final query combining:
 	// - Base filters from the input query
-	// - Version counting using LEFT JOIN
+	// - Version counting using LEFT JOIN, skipping soft-deleted versions
+	//   unless showDeleted is set and versions that are not released when
+	//   releasedOnly is set
 	// - Grouping to handle the aggregate
 	// - Sorting and pagination
-	result := query.
-		Select("services.*, COALESCE(COUNT(versions.id), 0) as version_count").
-		Joins("LEFT JOIN versions ON versions.service_id = services.id").
-		Group("services.id").
+	result := withVersionCount(query, params).
 		Order(sortColumn + " " + direction).
 		Offset(offset).
 		Limit(params.PageSize).
@@ -208,8 +266,120 @@ func (h *Handler) fetchListServices(c *gin.Context, params QueryParams, query *g
 			Details: result.Error.Error(),
 		})
 
-		return nil, 0
+		return nil, 0, false
+	}
+
+	return services, totalCount, true
+}
+
+// filterServices applies the search, label selector, attribute, missing
+// link, lifecycle and owner filters of params to a services query. When a
+// filter is invalid a 400 response is written and false is returned.
+func filterServices(c *gin.Context, params QueryParams, query *gorm.DB) (*gorm.DB, bool) {
+	// Apply case-insensitive search on name and description
+	// ILIKE is PostgreSQL specific, provides better performance than LOWER()
+	if params.Search != "" {
+		query = query.Where("services.name ILIKE ? OR services.description ILIKE ?",
+			"%"+params.Search+"%", "%"+params.Search+"%")
+	}
+
+	// Apply the label selector; keys and values are passed as arguments
+	if params.Selector != "" {
+		selector, err := labels.ParseSelector(params.Selector)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrInvalidSelector,
+				Details: err.Error(),
+			})
+			return nil, false
+		}
+		if len(selector) > 0 {
+			condition, args := labelSelectorCondition(selector)
+			query = query.Where(condition, args...)
+		}
+	}
+
+	// Apply attribute filters given as attr.<path> parameters
+	filters, err := attributes.ParseFilters(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidAttrFilter,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	if len(filters) > 0 {
+		condition, args := attributeFilterCondition(filters)
+		query = query.Where(condition, args...)
+	}
+
+	// Only keep services without a link of the requested type
+	if params.MissingLink != "" {
+		if !models.LinkType(params.MissingLink).Valid() {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrBadRequest,
+				Details: constants.MissingLink + " " + fmt.Sprintf(constants.ErrFieldNotOneOf, validation.LinkTypeNames()),
+			})
+			return nil, false
+		}
+		query = query.Where("NOT EXISTS (SELECT 1 FROM service_links WHERE service_links.service_id = services.id AND service_links.type = ?)",
+			params.MissingLink)
+	}
+
+	// Restrict to services in the requested lifecycle stages
+	if params.Lifecycle != "" {
+		stages, ok := parseLifecycleFilter(c, params.Lifecycle)
+		if !ok {
+			return nil, false
+		}
+		query = query.Where("services.lifecycle IN ?", stages)
+	}
+
+	// Restrict to services of one team, referenced by ID or by name
+	if params.Owner != "" {
+		if ownerID, err := strconv.ParseUint(params.Owner, 10, 64); err == nil {
+			query = query.Where("services.owner_id = ?", ownerID)
+		} else {
+			query = query.Where("services.owner_id IN (SELECT id FROM teams WHERE name = ? AND deleted_at IS NULL)",
+				params.Owner)
+		}
+	}
+
+	return query, true
+}
+
+// withVersionCount selects the services of query together with their
+// version count as a serviceWithVersion, using a LEFT JOIN on versions
+// grouped by service. Soft-deleted versions are skipped unless showDeleted
+// is set, and versions that are not released when releasedOnly is set.
+// Whether the latest version has a specification is selected as well.
+func withVersionCount(query *gorm.DB, params QueryParams) *gorm.DB {
+	versionJoin := "LEFT JOIN versions ON versions.service_id = services.id AND versions.deleted_at IS NULL"
+	if params.ShowDeleted == constants.True {
+		versionJoin = "LEFT JOIN versions ON versions.service_id = services.id"
+	}
+	if params.ReleasedOnly == constants.True {
+		versionJoin += " AND versions.status = '" + string(models.VersionReleased) + "'"
+	}
+
+	return query.
+		Select("services.*, COALESCE(COUNT(versions.id), 0) as version_count, "+
+			fmt.Sprintf(latestSpecCondition, "services.id")+" as latest_version_has_spec", unlistedStatuses).
+		Joins(versionJoin).
+		Group("services.id")
+}
+
+// queryContext derives the context used for database queries of a request.
+// It keeps the request's deadline when one is set and otherwise times out
+// after 5s, so slow queries cannot hang the server.
+func queryContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	timeoutDuration := 5 * time.Second
+	if deadline, ok := c.Request.Context().Deadline(); ok {
+		timeoutDuration = time.Until(deadline)
 	}
 
-	return services, totalCount
+	return context.WithTimeout(c.Request.Context(), timeoutDuration)
 }
@@ -0,0 +1,83 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+)
+
+// RestoreService handles POST /services/:id/restore endpoint.
+//
+// Reverses a soft deletion performed by DeleteService by clearing the
+// deleted_at column of the service and of the versions deleted with it.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Returns:
+//
+//	200: ServiceResponse of the restored service
+//	400: Invalid service ID
+//	404: Service never existed or was purged
+//	409: Service is not deleted
+//	500: Database error
+//
+// Notes:
+//   - Only versions whose deleted_at matches the service's are restored;
+//     versions deleted individually beforehand stay deleted
+//
+// Example:
+//
+//	POST /services/1/restore
+func (h *Handler) RestoreService(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, true)
+	if !ok {
+		return
+	}
+	if !service.DeletedAt.Valid {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrServiceNotDeleted,
+		})
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Version{}).
+			Where("service_id = ? AND deleted_at = ?", service.ID, service.DeletedAt.Time).
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(service).Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceRestoreFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.serviceResponse(service, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,404 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// serviceFields lists the fields of a service document that a patch may
+// reference. Writable fields map to true, read-only fields to false; any
+// other field is unknown and rejected.
+var serviceFields = map[string]bool{
+	"id":          false,
+	"name":        true,
+	"slug":        true,
+	"description": true,
+	"owner_id":    true,
+	"lifecycle":   false,
+	"attributes":  true,
+	"created_at":  false,
+	"updated_at":  false,
+}
+
+// UpdateService handles PUT /services/:id endpoint.
+//
+// Replaces every writable field of a service. Omitted fields are reset
+// to their zero value, so clients should send the complete representation.
+// The slug is the exception: it keeps its current value when omitted so
+// that renaming a service does not change its URLs.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body:
+//   - name (string): Service name, required
+//   - slug (string): URL-safe identifier, unchanged when omitted
+//   - description (string): Service description
+//   - owner_id (int): ID of the owning team, the service is unowned when omitted
+//   - attributes (object): Custom attributes, validated against the attribute schema
+//
+// Returns:
+//
+//	200: ServiceResponse of the updated service
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	409: Slug already used by another service
+//	422: Field validation failed, the owner team does not exist or the
+//	     attributes do not match the schema
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/1
+//	{"name": "Auth Service", "description": "Handles authentication"}
+func (h *Handler) UpdateService(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	h.saveService(c, service, req)
+}
+
+// PatchService handles PATCH /services/:id endpoint.
+//
+// Applies a partial update to a service. The patch format is selected by
+// the Content-Type header:
+//   - application/merge-patch+json: JSON Merge Patch (RFC 7396)
+//   - application/json-patch+json: JSON Patch (RFC 6902)
+//
+// Only writable fields may be changed. Operations touching read-only
+// fields (id, lifecycle, created_at, updated_at) or unknown fields are
+// rejected; the lifecycle stage changes through TransitionServiceLifecycle.
+// Attributes may be patched member by member, e.g. with the path
+// /attributes/costCenter.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Returns:
+//
+//	200: ServiceResponse of the updated service
+//	400: Invalid service ID or malformed patch document
+//	404: Service not found
+//	409: A JSON Patch test operation failed, or the slug is taken
+//	415: Unsupported Content-Type
+//	422: Patch touches read-only or unknown fields, cannot be applied,
+//	     or the result fails validation
+//	500: Database error
+//
+// Example:
+//
+//	PATCH /services/1
+//	Content-Type: application/merge-patch+json
+//	{"description": "Handles authentication and SSO"}
+func (h *Handler) PatchService(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != constants.ContentTypeMergePatch && contentType != constants.ContentTypeJSONPatch {
+		c.JSON(http.StatusUnsupportedMediaType, &constants.ServiceError{
+			Status:  constants.StatusUnsupportedMedia,
+			Message: constants.ErrUnsupportedPatchType,
+			Details: contentType,
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	service, ok := h.findService(c, serviceID, false)
+	if !ok {
+		return
+	}
+
+	document, err := json.Marshal(serviceDocument(service))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrPatchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var patched []byte
+	if contentType == constants.ContentTypeMergePatch {
+		patched, ok = applyMergePatch(c, document, body)
+	} else {
+		patched, ok = applyJSONPatch(c, document, body)
+	}
+	if !ok {
+		return
+	}
+
+	var req UpdateServiceRequest
+	if err := json.Unmarshal(patched, &req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrPatchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.saveService(c, service, req)
+}
+
+// saveService validates the requested field values, persists them on the
+// service and writes the updated ServiceResponse. GORM bumps UpdatedAt on save.
+func (h *Handler) saveService(c *gin.Context, service *models.Service, req UpdateServiceRequest) {
+	if validationErr := validation.NewValidationError(
+		validation.ValidateServiceName(req.Name),
+		validation.ValidateSlug(req.Slug),
+		validation.ValidateServiceDescription(req.Description),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	if !h.validateAttributes(c, req.Attributes) {
+		return
+	}
+
+	owner, ok := h.findOwner(c, req.OwnerID)
+	if !ok {
+		return
+	}
+
+	if req.Slug != "" && req.Slug != service.Slug {
+		if !h.checkSlugAvailable(c, req.Slug, service.ID) {
+			return
+		}
+		service.Slug = req.Slug
+	}
+	service.Name = strings.TrimSpace(req.Name)
+	service.Description = req.Description
+	service.OwnerID = req.OwnerID
+	service.Owner = owner
+	service.Attributes = req.Attributes
+
+	// Omit the associations so that GORM neither upserts the team and
+	// labels nor overwrites OwnerID from the loaded team
+	if result := h.db.Omit(clause.Associations).Save(service); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			writeSlugConflict(c, service.Slug)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceUpdateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	response, err := h.serviceResponse(service, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// applyMergePatch applies an RFC 7396 merge patch to document after checking
+// that it only sets writable fields. On failure the error response has
+// already been written and false is returned.
+func applyMergePatch(c *gin.Context, document, patch []byte) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidPatch,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	var fieldErrors []*constants.FieldError
+	for _, field := range names {
+		fieldErrors = append(fieldErrors, checkPatchField(field, true))
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+
+	patched, err := jsonpatch.MergePatch(document, patch)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrPatchFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return patched, true
+}
+
+// applyJSONPatch applies an RFC 6902 patch to document after checking that
+// every operation only writes writable fields. Test and copy operations may
+// read read-only fields. On failure the error response has already been
+// written and false is returned.
+func applyJSONPatch(c *gin.Context, document, body []byte) ([]byte, bool) {
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidPatch,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+
+	var fieldErrors []*constants.FieldError
+	for _, op := range patch {
+		path, err := op.Path()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrInvalidPatch,
+				Details: err.Error(),
+			})
+			return nil, false
+		}
+
+		switch op.Kind() {
+		case "test":
+			fieldErrors = append(fieldErrors, checkPatchField(pointerField(path), false))
+		case "copy", "move":
+			from, err := op.From()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, &constants.ServiceError{
+					Status:  constants.StatusBadRequest,
+					Message: constants.ErrInvalidPatch,
+					Details: err.Error(),
+				})
+				return nil, false
+			}
+			fieldErrors = append(fieldErrors,
+				checkPatchField(pointerField(from), op.Kind() == "move"),
+				checkPatchField(pointerField(path), true))
+		default:
+			fieldErrors = append(fieldErrors, checkPatchField(pointerField(path), true))
+		}
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+
+	patched, err := patch.Apply(document)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			c.JSON(http.StatusConflict, &constants.ServiceError{
+				Status:  constants.StatusConflict,
+				Message: constants.ErrPatchTestFailed,
+				Details: err.Error(),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrPatchFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return patched, true
+}
+
+// serviceDocument returns the JSON representation of a service that
+// patches are applied to. Its members match serviceFields.
+func serviceDocument(service *models.Service) map[string]interface{} {
+	// Attributes are always an object so that patches can add members
+	attributes := service.Attributes
+	if attributes == nil {
+		attributes = models.Attributes{}
+	}
+
+	return map[string]interface{}{
+		"id":          service.ID,
+		"name":        service.Name,
+		"slug":        service.Slug,
+		"description": service.Description,
+		"owner_id":    service.OwnerID,
+		"lifecycle":   service.Lifecycle,
+		"attributes":  attributes,
+		"created_at":  service.CreatedAt,
+		"updated_at":  service.UpdatedAt,
+	}
+}
+
+// checkPatchField reports whether field may be referenced by a patch.
+// Writes require a writable field; reads accept any known field.
+func checkPatchField(field string, write bool) *constants.FieldError {
+	writable, known := serviceFields[field]
+	if !known {
+		return &constants.FieldError{Field: field, Message: constants.ErrFieldUnknown}
+	}
+	if write && !writable {
+		return &constants.FieldError{Field: field, Message: constants.ErrFieldReadOnly}
+	}
+	return nil
+}
+
+// pointerField returns the top-level member named by a JSON Pointer,
+// decoding the ~1 and ~0 escapes.
+func pointerField(pointer string) string {
+	field := strings.TrimPrefix(pointer, "/")
+	if i := strings.Index(field, "/"); i >= 0 {
+		field = field[:i]
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(field)
+}
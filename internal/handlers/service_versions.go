@@ -2,39 +2,125 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"net/http"
 	"serviceCatalog/internal/constants"
 	"serviceCatalog/internal/models"
-	"serviceCatalog/internal/validation"
+	"serviceCatalog/internal/semver"
+	"strings"
 )
 
 // GetServiceVersions handles GET /services/:id/versions endpoint.
 //
-// Retrieves all versions associated with a service.
-// Returns versions in chronological order.
+// Retrieves a paginated list of versions associated with a service.
+// By default versions are ordered by semantic version precedence, lowest first.
 //
 // URL Parameters:
-//   - id (uint): Service ID
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - page (int): Page number, starting from 1
+//   - pageSize (int): Number of items per page (default: 10, max: 100)
+//   - sortBy (string): "number" (semver precedence) or "created_at"
+//   - sortDir (string): Sort direction ("asc", "desc")
+//   - createdAfter (RFC 3339): Only versions created after this time
+//   - createdBefore (RFC 3339): Only versions created before this time
+//   - range (string): Only versions satisfying a semver range,
+//     e.g. "^1.2", "~1.4.0" or ">=2.0.0 <3.0.0"
+//   - status (string): Only versions in these statuses, e.g. "released,deprecated"
+//   - showDeleted (bool): Include soft-deleted versions if true
 //
 // Returns:
 //
-//	200: []Version - List of service versions
-//	400: Invalid service ID
+//	200: ListVersionsResponse with the page of versions and total count
+//	400: Invalid service ID or query parameters
+//	404: Service not found
 //	500: Database error
 //
 // Example:
 //
-//	GET /services/1/versions
+//	GET /services/1/versions?range=^1.2&sortBy=created_at&sortDir=desc&page=1&pageSize=20
 func (h *Handler) GetServiceVersions(c *gin.Context) {
-	serviceID, validationErr := validation.ValidateServiceID(c)
-	if validationErr != nil {
-		c.JSON(validationErr.Status, validationErr)
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var params VersionQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	showDeleted := params.ShowDeleted == constants.True
+	if _, ok := h.findService(c, serviceID, showDeleted); !ok {
 		return
 	}
 
-	var versions []models.Version
-	result := h.db.Where("service_id = ?", serviceID).Find(&versions)
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	query := h.db.WithContext(ctx).Model(&models.Version{})
+	if showDeleted {
+		query = query.Unscoped()
+	}
+	query = query.Where("versions.service_id = ?", serviceID)
+
+	if params.Range != "" {
+		constraint, err := semver.ParseConstraint(params.Range)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrInvalidRange,
+				Details: err.Error(),
+			})
+			return
+		}
+		condition, args := versionRangeCondition(constraint)
+		query = query.Where(condition, args...)
+	}
+	if params.Status != "" {
+		statuses, ok := parseVersionStatusFilter(c, params.Status)
+		if !ok {
+			return
+		}
+		query = query.Where("versions.status IN ?", statuses)
+	}
+	if !params.CreatedAfter.IsZero() {
+		query = query.Where("versions.created_at > ?", params.CreatedAfter)
+	}
+	if !params.CreatedBefore.IsZero() {
+		query = query.Where("versions.created_at < ?", params.CreatedBefore)
+	}
+
+	// Get total count before pagination for metadata
+	var totalCount int64
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	order := versionPrecedenceOrder(params.SortDir)
+	if params.SortBy == constants.CreatedAt {
+		order = fmt.Sprintf("versions.created_at %[1]s, versions.id %[1]s", params.SortDir)
+	}
+
+	versions := []models.Version{}
+	result := query.
+		Order(order).
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&versions)
 
 	if result.Error != nil {
 
@@ -46,5 +132,54 @@ func (h *Handler) GetServiceVersions(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, versions)
+	c.JSON(http.StatusOK, ListVersionsResponse{
+		ServiceID:   serviceID,
+		Versions:    versions,
+		TotalCount:  totalCount,
+		CurrentPage: params.Page,
+		PageSize:    params.PageSize,
+	})
+}
+
+// versionPrecedenceOrder returns an ORDER BY clause sorting versions by
+// semantic version precedence. The pre-release key is compared byte-wise,
+// see semver.Version.PreReleaseKey.
+func versionPrecedenceOrder(direction string) string {
+	return fmt.Sprintf(`versions.major %[1]s, versions.minor %[1]s, versions.patch %[1]s, versions.pre_release_key COLLATE "C" %[1]s`, direction)
+}
+
+// versionRangeCondition compiles a semver range into a parameterized SQL
+// condition on the versions table mirroring semver.Comparator.Check.
+func versionRangeCondition(constraint *semver.Constraint) (string, []interface{}) {
+	const core = "(versions.major, versions.minor, versions.patch)"
+	const isRelease = "versions.pre_release = ''"
+
+	var alternatives []string
+	var args []interface{}
+	for _, set := range constraint.Sets() {
+		conditions := []string{"TRUE"}
+		for _, comparator := range set {
+			bound := []interface{}{comparator.Version.Major, comparator.Version.Minor, comparator.Version.Patch}
+			switch comparator.Op {
+			case semver.OpEqual:
+				conditions = append(conditions, core+" = (?, ?, ?) AND "+isRelease)
+				args = append(args, bound...)
+			case semver.OpGreater:
+				conditions = append(conditions, core+" > (?, ?, ?)")
+				args = append(args, bound...)
+			case semver.OpGreaterEqual:
+				conditions = append(conditions, "("+core+" > (?, ?, ?) OR ("+core+" = (?, ?, ?) AND "+isRelease+"))")
+				args = append(args, bound...)
+				args = append(args, bound...)
+			case semver.OpLess:
+				conditions = append(conditions, core+" < (?, ?, ?)")
+				args = append(args, bound...)
+			case semver.OpLessEqual:
+				conditions = append(conditions, core+" <= (?, ?, ?)")
+				args = append(args, bound...)
+			}
+		}
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
 }
@@ -0,0 +1,119 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// CreateTeam handles POST /teams endpoint.
+//
+// Creates a team that services can name as their owner.
+//
+// Request Body:
+//   - name (string): Team name, required and unique
+//   - contact_email (string): Optional email address of the team
+//   - chat_channel (string): Optional chat channel, e.g. "#payments"
+//
+// Returns:
+//
+//	201: Team - The created team, with a Location header
+//	400: Malformed JSON body
+//	409: Team name already in use
+//	422: Field validation failed
+//	500: Database error
+//
+// Example:
+//
+//	POST /teams
+//	{"name": "Payments", "contact_email": "payments@example.com", "chat_channel": "#payments"}
+func (h *Handler) CreateTeam(c *gin.Context) {
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if validationErr := validateTeamRequest(req); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	team := models.Team{
+		Name:         strings.TrimSpace(req.Name),
+		ContactEmail: req.ContactEmail,
+		ChatChannel:  req.ChatChannel,
+	}
+
+	if !h.checkTeamNameAvailable(c, team.Name, 0) {
+		return
+	}
+
+	if result := h.db.Create(&team); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			writeTeamConflict(c, team.Name)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamCreateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/teams/%d", team.ID))
+	c.JSON(http.StatusCreated, team)
+}
+
+// validateTeamRequest validates every field of a team request body.
+func validateTeamRequest(req TeamRequest) *constants.ServiceError {
+	return validation.NewValidationError(
+		validation.ValidateTeamName(req.Name),
+		validation.ValidateContactEmail(req.ContactEmail),
+		validation.ValidateChatChannel(req.ChatChannel),
+	)
+}
+
+// checkTeamNameAvailable reports whether name is unused by teams other than
+// exceptID, writing a 409 or 500 response otherwise. Names of soft-deleted
+// teams stay reserved by the unique index.
+func (h *Handler) checkTeamNameAvailable(c *gin.Context, name string, exceptID uint) bool {
+	var count int64
+	err := h.db.Unscoped().Model(&models.Team{}).
+		Where("name = ? AND id <> ?", name, exceptID).
+		Count(&count).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamFetchFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+	if count > 0 {
+		writeTeamConflict(c, name)
+		return false
+	}
+	return true
+}
+
+// writeTeamConflict writes the 409 response for a team name that is already taken.
+func writeTeamConflict(c *gin.Context, name string) {
+	c.JSON(http.StatusConflict, &constants.ServiceError{
+		Status:  constants.StatusConflict,
+		Message: constants.ErrTeamExists,
+		Details: name,
+	})
+}
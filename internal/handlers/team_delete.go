@@ -0,0 +1,72 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+)
+
+// DeleteTeam handles DELETE /teams/:id endpoint.
+//
+// Soft deletes a team. A team that still owns services cannot be deleted;
+// reassign or delete its services first.
+//
+// URL Parameters:
+//   - id (int): Team ID
+//
+// Returns:
+//
+//	204: Team deleted
+//	400: Invalid team ID
+//	404: Team not found
+//	409: Team still owns services
+//	500: Database error
+//
+// Example:
+//
+//	DELETE /teams/1
+func (h *Handler) DeleteTeam(c *gin.Context) {
+	teamID, validationErr := validation.ValidateTeamID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	team, ok := h.findTeam(c, teamID)
+	if !ok {
+		return
+	}
+
+	var owned int64
+	if err := h.db.Model(&models.Service{}).Where("owner_id = ?", team.ID).Count(&owned).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceCountFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+	if owned > 0 {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrTeamOwnsServices,
+			Details: fmt.Sprintf("team owns %d services", owned),
+		})
+		return
+	}
+
+	if result := h.db.Delete(team); result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamDeleteFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,137 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+)
+
+// GetTeam handles GET /teams/:id endpoint.
+//
+// URL Parameters:
+//   - id (int): Team ID
+//
+// Returns:
+//
+//	200: Team
+//	400: Invalid team ID
+//	404: Team not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /teams/1
+func (h *Handler) GetTeam(c *gin.Context) {
+	teamID, validationErr := validation.ValidateTeamID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	team, ok := h.findTeam(c, teamID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// ListTeams handles GET /teams endpoint.
+//
+// Query Parameters:
+//   - page (int): Page number, starting from 1
+//   - pageSize (int): Number of items per page (default: 10, max: 100)
+//   - search (string): Case-insensitive search in the team name
+//   - sortBy (string): "id" (default) or "name"
+//   - sortDir (string): "asc" (default) or "desc"
+//
+// Returns:
+//
+//	200: ListTeamsResponse
+//	400: Invalid query parameters
+//	500: Database error
+//
+// Example:
+//
+//	GET /teams?search=pay&sortBy=name
+func (h *Handler) ListTeams(c *gin.Context) {
+	var params TeamQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	query := h.db.WithContext(ctx).Model(&models.Team{})
+	if params.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+params.Search+"%")
+	}
+
+	var totalCount int64
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	teams := make([]models.Team, 0, params.PageSize)
+	err := query.
+		Order(params.SortBy + " " + params.SortDir).
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&teams).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListTeamsResponse{
+		Teams:       teams,
+		TotalCount:  totalCount,
+		CurrentPage: params.Page,
+		PageSize:    params.PageSize,
+	})
+}
+
+// findTeam loads a team by ID, writing a 404 or 500 response when it
+// cannot be loaded. Soft-deleted teams are not returned.
+func (h *Handler) findTeam(c *gin.Context, teamID uint64) (*models.Team, bool) {
+	var team models.Team
+	result := h.db.First(&team, teamID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, &constants.ServiceError{
+				Status:  constants.StatusNotFound,
+				Message: constants.ErrTeamNotFound,
+				Details: result.Error.Error(),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return nil, false
+	}
+
+	return &team, true
+}
@@ -0,0 +1,66 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strconv"
+)
+
+// ListTeamServices handles GET /teams/:id/services endpoint.
+//
+// Lists the services owned by a team. It accepts the same query
+// parameters and returns the same paginated envelope as ListServices;
+// the owner parameter is ignored.
+//
+// URL Parameters:
+//   - id (int): Team ID
+//
+// Returns:
+//
+//	200: ListServicesResponse
+//	400: Invalid team ID or query parameters
+//	404: Team not found
+//	500: Database error
+//
+// Example:
+//
+//	GET /teams/1/services?page=1&pageSize=20&sortBy=name
+func (h *Handler) ListTeamServices(c *gin.Context) {
+	teamID, validationErr := validation.ValidateTeamID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var params QueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	team, ok := h.findTeam(c, teamID)
+	if !ok {
+		return
+	}
+	params.Owner = strconv.FormatUint(uint64(team.ID), 10)
+
+	query := h.db.Model(&models.Service{})
+	if params.ShowDeleted == constants.True {
+		query = query.Unscoped()
+	}
+
+	services, totalCount, ok := h.fetchListServices(c, params, query)
+	if !ok {
+		return
+	}
+
+	h.writeServiceList(c, params, services, totalCount)
+}
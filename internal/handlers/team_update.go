@@ -0,0 +1,88 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// UpdateTeam handles PUT /teams/:id endpoint.
+//
+// Replaces every field of a team; omitted fields are reset to their zero value.
+//
+// URL Parameters:
+//   - id (int): Team ID
+//
+// Request Body:
+//   - name (string): Team name, required and unique
+//   - contact_email (string): Email address of the team
+//   - chat_channel (string): Chat channel of the team
+//
+// Returns:
+//
+//	200: Team - The updated team
+//	400: Invalid team ID or malformed body
+//	404: Team not found
+//	409: Team name already in use
+//	422: Field validation failed
+//	500: Database error
+//
+// Example:
+//
+//	PUT /teams/1
+//	{"name": "Payments", "contact_email": "payments@example.com", "chat_channel": "#payments-oncall"}
+func (h *Handler) UpdateTeam(c *gin.Context) {
+	teamID, validationErr := validation.ValidateTeamID(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if validationErr := validateTeamRequest(req); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	team, ok := h.findTeam(c, teamID)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name != team.Name && !h.checkTeamNameAvailable(c, name, team.ID) {
+		return
+	}
+	team.Name = name
+	team.ContactEmail = req.ContactEmail
+	team.ChatChannel = req.ChatChannel
+
+	if result := h.db.Save(team); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			writeTeamConflict(c, team.Name)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrTeamUpdateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
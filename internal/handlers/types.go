@@ -1,6 +1,13 @@
 package handlers
 
-import "serviceCatalog/internal/models"
+import (
+	"encoding/json"
+	"serviceCatalog/internal/changelog"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/openapi"
+	"serviceCatalog/internal/protoset"
+	"time"
+)
 
 type ListServicesResponse struct {
 	Services    []models.ServiceResponse `json:"services"`
@@ -9,11 +16,359 @@ type ListServicesResponse struct {
 	PageSize    int                      `json:"page_size"`
 }
 
+type ListVersionsResponse struct {
+	ServiceID   uint64           `json:"service_id"`
+	Versions    []models.Version `json:"versions"`
+	TotalCount  int64            `json:"total_count"`
+	CurrentPage int              `json:"current_page"`
+	PageSize    int              `json:"page_size"`
+}
+
+// LabelsResponse is returned by the /services/:id/labels endpoints.
+type LabelsResponse struct {
+	ServiceID uint              `json:"service_id"`
+	Labels    map[string]string `json:"labels"`
+}
+
+// AttributeSchemaResponse is returned by the /attributes/schema endpoints.
+type AttributeSchemaResponse struct {
+	Schema    json.RawMessage `json:"schema"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// LinksResponse is returned by GET /services/:id/links.
+type LinksResponse struct {
+	ServiceID uint                 `json:"service_id"`
+	Links     []models.ServiceLink `json:"links"`
+}
+
+// LifecycleResponse is returned by the /services/:id/lifecycle endpoints.
+// Next lists the stages the service may move to.
+type LifecycleResponse struct {
+	ServiceID uint                         `json:"service_id"`
+	Stage     models.LifecycleStage        `json:"stage"`
+	Next      []models.LifecycleStage      `json:"next"`
+	History   []models.LifecycleTransition `json:"history"`
+}
+
+// ChangelogResponse is the JSON form of GET /services/:id/changelog.
+// Entries are ordered newest first by semantic version precedence.
+type ChangelogResponse struct {
+	ServiceID uint              `json:"service_id"`
+	Name      string            `json:"name"`
+	Entries   []changelog.Entry `json:"entries"`
+}
+
+// CompareVersionsResponse lists the changes between the OpenAPI
+// specifications of two versions, see GET /services/:id/versions/compare.
+// Breaking tells whether any change is breaking and MajorIncreased whether
+// the major version number of To is higher than that of From.
+type CompareVersionsResponse struct {
+	ServiceID      uint             `json:"service_id"`
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	Breaking       bool             `json:"breaking"`
+	MajorIncreased bool             `json:"major_increased"`
+	Changes        []openapi.Change `json:"changes"`
+}
+
+// DescriptorSetResponse describes the FileDescriptorSet of a version: the
+// files it was compiled from and the gRPC services they define.
+type DescriptorSetResponse struct {
+	VersionID uint               `json:"version_id"`
+	Files     []string           `json:"files"`
+	Services  []protoset.Service `json:"services"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// CompatibilityResponse lists the wire-level changes from the descriptor
+// set of Against to that of Version, see GET
+// /services/:id/versions/:version/proto/compatibility. Against is empty
+// when there was nothing to compare with; Compatible is false when any
+// change is breaking.
+type CompatibilityResponse struct {
+	ServiceID  uint              `json:"service_id"`
+	Version    string            `json:"version"`
+	Against    string            `json:"against,omitempty"`
+	Compatible bool              `json:"compatible"`
+	Changes    []protoset.Change `json:"changes"`
+}
+
+// VersionResponse is a single version with the ranges of versions of
+// other services it requires, sorted by service slug.
+type VersionResponse struct {
+	models.Version
+	Requires []RequirementResponse `json:"requires,omitempty"`
+}
+
+// RequirementsResponse lists the ranges of versions of other services a
+// version needs, sorted by service slug.
+type RequirementsResponse struct {
+	VersionID uint                  `json:"version_id"`
+	Requires  []RequirementResponse `json:"requires"`
+}
+
+// RequirementResponse is a range of versions of a service a version needs.
+type RequirementResponse struct {
+	ServiceID uint   `json:"service_id"`
+	Service   string `json:"service"`
+	Range     string `json:"range"`
+}
+
+// ResolveResponse is the outcome of GET /resolve. When Resolved is set,
+// Versions holds one version of every requested service and of every
+// service they require; otherwise Conflict explains why there is none.
+type ResolveResponse struct {
+	Resolved bool              `json:"resolved"`
+	Versions []ResolvedVersion `json:"versions"`
+	Conflict *ResolveConflict  `json:"conflict,omitempty"`
+}
+
+// ResolvedVersion is the version picked for a service. Requested is false
+// for services pulled in by the requirements of another.
+type ResolvedVersion struct {
+	ServiceID uint   `json:"service_id"`
+	Service   string `json:"service"`
+	VersionID uint   `json:"version_id"`
+	Version   string `json:"version"`
+	Requested bool   `json:"requested"`
+}
+
+// ResolveConflict names the service no version could be picked for, the
+// ranges the versions picked for other services required of it and why
+// each of its released versions was rejected.
+type ResolveConflict struct {
+	ServiceID  uint                  `json:"service_id"`
+	Service    string                `json:"service"`
+	Message    string                `json:"message"`
+	RequiredBy []ConflictRequirement `json:"required_by"`
+	Rejected   []RejectedVersion     `json:"rejected"`
+}
+
+// ConflictRequirement is a range required by a picked version.
+type ConflictRequirement struct {
+	Service string `json:"service"`
+	Version string `json:"version"`
+	Range   string `json:"range"`
+}
+
+// RejectedVersion is a version that could not be picked and why.
+type RejectedVersion struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+// SBOMResponse describes the SBOM of a version with its normalized
+// components, sorted by package URL and name.
+type SBOMResponse struct {
+	VersionID   uint                   `json:"version_id"`
+	Format      string                 `json:"format"`
+	SpecVersion string                 `json:"spec_version"`
+	Components  []models.SBOMComponent `json:"components"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// ListComponentsResponse lists the service versions whose SBOM contains
+// the package PURL, see GET /components.
+type ListComponentsResponse struct {
+	PURL        string           `json:"purl"`
+	Versions    []ComponentUsage `json:"versions"`
+	TotalCount  int64            `json:"total_count"`
+	CurrentPage int              `json:"current_page"`
+	PageSize    int              `json:"page_size"`
+}
+
+// ComponentUsage is a service version and the component of its SBOM
+// matching a package URL. A version listing the package more than once,
+// e.g. with different qualifiers, appears once per component.
+type ComponentUsage struct {
+	ServiceID uint                 `json:"service_id"`
+	Service   string               `json:"service"`
+	VersionID uint                 `json:"version_id"`
+	Version   string               `json:"version"`
+	Status    models.VersionStatus `json:"status"`
+	Component models.SBOMComponent `json:"component"`
+}
+
+// DependencyResponse is a dependency edge seen from one of its services;
+// Service is the service at the other end.
+type DependencyResponse struct {
+	ID          uint                  `json:"id"`
+	Service     models.ServiceSummary `json:"service"`
+	Kind        models.DependencyKind `json:"kind"`
+	Criticality models.Criticality    `json:"criticality,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// DependenciesResponse is returned by GET /services/:id/dependencies.
+type DependenciesResponse struct {
+	ServiceID    uint                 `json:"service_id"`
+	Dependencies []DependencyResponse `json:"dependencies"`
+}
+
+// DependentsResponse is returned by GET /services/:id/dependents.
+type DependentsResponse struct {
+	ServiceID  uint                 `json:"service_id"`
+	Dependents []DependencyResponse `json:"dependents"`
+}
+
+// ImpactedService is a service affected by an outage of the analyzed
+// service. Path lists the slugs from the analyzed service to this one
+// along a shortest chain of dependents; Distance is its number of hops.
+type ImpactedService struct {
+	Service  models.ServiceSummary `json:"service"`
+	Distance int                   `json:"distance"`
+	Path     []string              `json:"path"`
+}
+
+// ImpactResponse is returned by GET /services/:id/impact. Cycles lists
+// dependency cycles met during the analysis as slug paths ending at the
+// service that closes the cycle.
+type ImpactResponse struct {
+	ServiceID uint              `json:"service_id"`
+	Depth     int               `json:"depth"`
+	Affected  []ImpactedService `json:"affected"`
+	Cycles    [][]string        `json:"cycles"`
+}
+
+type ListTeamsResponse struct {
+	Teams       []models.Team `json:"teams"`
+	TotalCount  int64         `json:"total_count"`
+	CurrentPage int           `json:"current_page"`
+	PageSize    int           `json:"page_size"`
+}
+
 type QueryParams struct {
-	Page        int    `form:"page,default=1"`
-	PageSize    int    `form:"pageSize,default=10"`
-	Search      string `form:"search"`
-	SortBy      string `form:"sortBy,default=id"`
-	SortDir     string `form:"sortDir,default=asc"`
-	ShowDeleted string `form:"showDeleted"`
+	Page         int    `form:"page,default=1"`
+	PageSize     int    `form:"pageSize,default=10"`
+	Search       string `form:"search"`
+	SortBy       string `form:"sortBy,default=id"`
+	SortDir      string `form:"sortDir,default=asc"`
+	ShowDeleted  string `form:"showDeleted"`
+	Owner        string `form:"owner"`
+	Selector     string `form:"selector"`
+	MissingLink  string `form:"missingLink"`
+	Lifecycle    string `form:"lifecycle"`
+	ReleasedOnly string `form:"releasedOnly"`
+}
+
+type TeamQueryParams struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"pageSize,default=10" binding:"min=1,max=100"`
+	Search   string `form:"search"`
+	SortBy   string `form:"sortBy,default=id" binding:"oneof=id name"`
+	SortDir  string `form:"sortDir,default=asc" binding:"oneof=asc desc"`
+}
+
+type VersionQueryParams struct {
+	Page          int       `form:"page,default=1" binding:"min=1"`
+	PageSize      int       `form:"pageSize,default=10" binding:"min=1,max=100"`
+	SortBy        string    `form:"sortBy,default=number" binding:"oneof=number created_at"`
+	SortDir       string    `form:"sortDir,default=asc" binding:"oneof=asc desc"`
+	CreatedAfter  time.Time `form:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore time.Time `form:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+	Range         string    `form:"range"`
+	Status        string    `form:"status"`
+	ShowDeleted   string    `form:"showDeleted"`
+}
+
+// ComponentQueryParams are the query parameters of GET /components.
+type ComponentQueryParams struct {
+	Page         int    `form:"page,default=1" binding:"min=1"`
+	PageSize     int    `form:"pageSize,default=10" binding:"min=1,max=100"`
+	PURL         string `form:"purl"`
+	ReleasedOnly string `form:"releasedOnly"`
+}
+
+type ImpactQueryParams struct {
+	Depth int `form:"depth,default=5" binding:"min=1,max=10"`
+}
+
+// GraphQueryParams are the query parameters of GET /services/:id/graph.
+// Of the embedded QueryParams only showDeleted and releasedOnly apply.
+type GraphQueryParams struct {
+	QueryParams
+	Depth int `form:"depth,default=1" binding:"min=1,max=10"`
+}
+
+// CreateServiceRequest is the request body accepted by POST /services.
+// Slug is optional and generated from Name when empty. Lifecycle defaults
+// to proposed. OwnerID, Labels and Attributes are optional.
+type CreateServiceRequest struct {
+	Name        string            `json:"name"`
+	Slug        string            `json:"slug"`
+	Description string            `json:"description"`
+	OwnerID     *uint             `json:"owner_id"`
+	Lifecycle   string            `json:"lifecycle"`
+	Labels      map[string]string `json:"labels"`
+	Attributes  models.Attributes `json:"attributes"`
+}
+
+// UpdateServiceRequest is the request body accepted by PUT /services/:id.
+// Every writable field is replaced; omitted fields are reset to their zero
+// value, except Slug which keeps its current value when empty.
+type UpdateServiceRequest struct {
+	Name        string            `json:"name"`
+	Slug        string            `json:"slug"`
+	Description string            `json:"description"`
+	OwnerID     *uint             `json:"owner_id"`
+	Attributes  models.Attributes `json:"attributes"`
+}
+
+// TeamRequest is the request body accepted by POST /teams and PUT /teams/:id.
+type TeamRequest struct {
+	Name         string `json:"name"`
+	ContactEmail string `json:"contact_email"`
+	ChatChannel  string `json:"chat_channel"`
+}
+
+// LinkRequest is the request body accepted by POST /services/:id/links
+// and PUT /services/:id/links/:linkId.
+type LinkRequest struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// LifecycleTransitionRequest is the request body accepted by
+// POST /services/:id/lifecycle.
+type LifecycleTransitionRequest struct {
+	Stage  string `json:"stage"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// DependencyRequest is the request body accepted by
+// POST /services/:id/dependencies. Criticality is optional.
+type DependencyRequest struct {
+	DependsOnID uint   `json:"depends_on_id"`
+	Kind        string `json:"kind"`
+	Criticality string `json:"criticality"`
+}
+
+// CreateVersionRequest is the request body accepted by POST /services/:id/versions.
+// Status is draft or released and defaults to released. ReleaseNotes are
+// optional Markdown. Requires maps service IDs or slugs to the version
+// ranges the new version needs.
+type CreateVersionRequest struct {
+	Number       string            `json:"number"`
+	Status       string            `json:"status"`
+	ReleaseNotes string            `json:"release_notes"`
+	Requires     map[string]string `json:"requires"`
+}
+
+// ReleaseNotesRequest is the request body accepted by
+// PUT /services/:id/versions/:version/notes.
+type ReleaseNotesRequest struct {
+	ReleaseNotes string `json:"release_notes"`
+}
+
+// VersionStatusRequest is the request body accepted by
+// POST /services/:id/versions/:version/status.
+type VersionStatusRequest struct {
+	Status          string     `json:"status"`
+	DeprecationDate *time.Time `json:"deprecation_date"`
+	EndOfLifeDate   *time.Time `json:"end_of_life_date"`
 }
@@ -0,0 +1,233 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/openapi"
+	"serviceCatalog/internal/validation"
+)
+
+// CompareVersions handles GET /services/:id/versions/compare endpoint.
+//
+// Compares the OpenAPI specifications of two versions and classifies
+// every change as breaking or not. Removed paths, operations, responses
+// and media types, changed types, new required request fields and
+// parameters, narrowed request enums, removed or optional response fields
+// and widened response enums are breaking.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - from (string): Version ID or number of the old version, required
+//   - to (string): Version ID or number of the new version, required
+//
+// Returns:
+//
+//	200: CompareVersionsResponse
+//	400: Invalid service ID or version reference
+//	404: Version not found or it has no specification
+//	422: The specifications are too complex to compare
+//	500: Database error or unreadable stored document
+//
+// Example:
+//
+//	GET /services/1/versions/compare?from=1.0.0&to=2.0.0
+func (h *Handler) CompareVersions(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var refs [2]validation.VersionRef
+	for i, name := range []string{constants.From, constants.To} {
+		value := c.Query(name)
+		if value == "" {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrInvalidVersionRef,
+				Details: name + " " + constants.ErrFieldRequired,
+			})
+			return
+		}
+		ref, validationErr := validation.ParseVersionRef(value)
+		if validationErr != nil {
+			c.JSON(validationErr.Status, validationErr)
+			return
+		}
+		refs[i] = ref
+	}
+
+	var versions [2]*models.Version
+	var docs [2]*openapi.Document
+	for i, ref := range refs {
+		version, ok := h.findVersion(c, serviceID, ref, false)
+		if !ok {
+			return
+		}
+		doc, err := h.specDocument(version.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrSpecCompareFailed,
+				Details: err.Error(),
+			})
+			return
+		}
+		if doc == nil {
+			c.JSON(http.StatusNotFound, &constants.ServiceError{
+				Status:  constants.StatusNotFound,
+				Message: constants.ErrSpecNotFound,
+				Details: "version " + version.Number + " has no specification",
+			})
+			return
+		}
+		versions[i], docs[i] = version, doc
+	}
+
+	changes, ok := compareSpecs(c, docs[0], docs[1])
+	if !ok {
+		return
+	}
+	if changes == nil {
+		changes = []openapi.Change{}
+	}
+	c.JSON(http.StatusOK, CompareVersionsResponse{
+		ServiceID:      versions[1].ServiceID,
+		From:           versions[0].Number,
+		To:             versions[1].Number,
+		Breaking:       openapi.HasBreaking(changes),
+		MajorIncreased: versions[1].Major > versions[0].Major,
+		Changes:        changes,
+	})
+}
+
+// compareSpecs compares two specifications, writing a 422 response and
+// returning false when they have too many schemas to compare within
+// constants.MaxCompareSteps.
+func compareSpecs(c *gin.Context, from, to *openapi.Document) ([]openapi.Change, bool) {
+	changes, err := openapi.Compare(from, to, constants.MaxCompareSteps)
+	if err != nil {
+		if errors.Is(err, openapi.ErrTooComplex) {
+			c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+				Status:  constants.StatusUnprocessableEntity,
+				Message: constants.ErrSpecTooComplex,
+				Details: fmt.Sprintf("gave up after comparing %d schemas", constants.MaxCompareSteps),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecCompareFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return changes, true
+}
+
+// specDocument loads and parses the specification of a version, returning
+// nil without an error when it has none.
+func (h *Handler) specDocument(versionID uint) (*openapi.Document, error) {
+	var spec models.VersionSpec
+	result := h.db.Where("version_id = ?", versionID).Limit(1).Find(&spec)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return openapi.Parse([]byte(spec.Document))
+}
+
+// checkBreakingChanges compares the stored specification of a version
+// about to be released with that of the closest published version before
+// it that has one, see checkBreakingDocument. Versions without a
+// specification pass.
+func (h *Handler) checkBreakingChanges(c *gin.Context, version *models.Version) bool {
+	doc, err := h.specDocument(version.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecCompareFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+	if doc == nil {
+		return true
+	}
+	return h.checkBreakingDocument(c, version, doc)
+}
+
+// checkBreakingDocument compares doc, the specification of version, with
+// that of the closest published version before it that has one. When
+// there are breaking changes and the major version number did not
+// increase, a 409 response is written and false is returned, and a 422
+// response when the documents are too complex to compare. Versions
+// without an earlier specification to compare with pass.
+func (h *Handler) checkBreakingDocument(c *gin.Context, version *models.Version, doc *openapi.Document) bool {
+	previous, err := h.previousPublished(version, "version_specs")
+	var previousDoc *openapi.Document
+	if err == nil && previous != nil {
+		previousDoc, err = h.specDocument(previous.ID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecCompareFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+	if previousDoc == nil || version.Major > previous.Major {
+		return true
+	}
+
+	changes, ok := compareSpecs(c, previousDoc, doc)
+	if !ok {
+		return false
+	}
+	var breaking []openapi.Change
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	if len(breaking) == 0 {
+		return true
+	}
+
+	c.JSON(http.StatusConflict, &constants.ServiceError{
+		Status:  constants.StatusConflict,
+		Message: constants.ErrBreakingChange,
+		Details: fmt.Sprintf("%d breaking changes since %s without a major version increase, first: %s; see GET /services/%d/versions/compare?from=%s&to=%s",
+			len(breaking), previous.Number, breaking[0].Message, version.ServiceID, previous.Number, version.Number),
+	})
+	return false
+}
+
+// previousPublished finds the closest released, deprecated or end-of-life
+// version before version that has a row in table, a contract table keyed
+// by version_id, returning nil without an error when there is none.
+func (h *Handler) previousPublished(version *models.Version, table string) (*models.Version, error) {
+	var previous models.Version
+	result := h.db.
+		Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.version_id = versions.id", table)).
+		Where("versions.service_id = ? AND versions.status IN ?", version.ServiceID, []models.VersionStatus{
+			models.VersionReleased, models.VersionDeprecated, models.VersionEndOfLife,
+		}).
+		Where(`((versions.major, versions.minor, versions.patch) < (?, ?, ?) OR
+			((versions.major, versions.minor, versions.patch) = (?, ?, ?) AND versions.pre_release_key COLLATE "C" < ?))`,
+			version.Major, version.Minor, version.Patch,
+			version.Major, version.Minor, version.Patch, version.PreReleaseKey).
+		Order(versionPrecedenceOrder(constants.DescSortOrder)).
+		Limit(1).
+		Find(&previous)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &previous, nil
+}
@@ -0,0 +1,151 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+)
+
+// CreateVersion handles POST /services/:id/versions endpoint.
+//
+// Publishes a new version of a service. The version number must be a
+// valid semantic version and unique within the service. Versions are
+// released unless published as drafts.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Request Body:
+//   - number (string): Semantic version, e.g. "2.1.0" or "3.0.0-rc.1"
+//   - status (string): Optional, "draft" or "released" (default)
+//   - release_notes (string): Optional Markdown describing the changes
+//   - requires (object): Optional service IDs or slugs mapped to the
+//     version ranges of them this version needs
+//
+// Returns:
+//
+//	201: VersionResponse - The created version with its requirements, with a Location header
+//	400: Invalid service ID or malformed body
+//	404: Service not found
+//	409: Version already exists, or the service is soft-deleted
+//	422: Invalid version number, status or requirement
+//	500: Database error
+//
+// Example:
+//
+//	POST /services/1/versions
+//	{"number": "2.1.0", "requires": {"payment-gateway": ">=2.0.0 <3.0.0"}}
+func (h *Handler) CreateVersion(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	number := strings.TrimSpace(req.Number)
+	if validationErr := validation.NewValidationError(
+		validation.ValidateVersionNumber(number),
+		validation.ValidatePublishStatus(req.Status),
+		validation.ValidateReleaseNotes(req.ReleaseNotes),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	// Load the service including soft-deleted rows so that publishing to a
+	// deleted service is reported as a conflict rather than a missing service
+	service, ok := h.findService(c, serviceID, true)
+	if !ok {
+		return
+	}
+	if service.DeletedAt.Valid {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrServiceDeleted,
+			Details: "cannot publish a version of a deleted service",
+		})
+		return
+	}
+
+	// Soft-deleted versions keep their number reserved
+	var existing int64
+	if err := h.db.Unscoped().Model(&models.Version{}).
+		Where("service_id = ? AND number = ?", service.ID, number).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrVersionExists,
+			Details: number,
+		})
+		return
+	}
+
+	requirements, ok := h.parseRequirements(c, service.ID, req.Requires)
+	if !ok {
+		return
+	}
+
+	version := models.Version{
+		ServiceID:    service.ID,
+		Number:       number,
+		Status:       models.VersionStatus(req.Status),
+		ReleaseNotes: req.ReleaseNotes,
+		Requires:     requirements,
+	}
+
+	if result := h.db.Create(&version); result.Error != nil {
+		// A concurrent request may have published the same number between
+		// the existence check and the insert
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			c.JSON(http.StatusConflict, &constants.ServiceError{
+				Status:  constants.StatusConflict,
+				Message: constants.ErrVersionExists,
+				Details: number,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionCreateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	requires, err := h.loadRequirements(version.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrRequirementsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/services/%d/versions/%d", service.ID, version.ID))
+	c.JSON(http.StatusCreated, VersionResponse{Version: version, Requires: requires})
+}
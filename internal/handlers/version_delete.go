@@ -0,0 +1,67 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/validation"
+)
+
+// DeleteVersion handles DELETE /services/:id/versions/:version endpoint.
+//
+// Soft-deletes a single version of a service. The version number stays
+// reserved and cannot be published again. Like deprecating it, deleting
+// a released or deprecated version is refused while released versions of
+// other services still require it, unless force=true is passed.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Query Parameters:
+//   - force (bool): Delete even when released consumers still require the version
+//
+// Returns:
+//
+//	204: Version successfully deleted
+//	400: Invalid service ID or version
+//	404: Version not found for this service
+//	409: Version is still required by released consumers
+//	500: Deletion failed
+//
+// Example:
+//
+//	DELETE /services/1/versions/3
+func (h *Handler) DeleteVersion(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	if mayBeRequired(version.Status) && c.Query(constants.Force) != constants.True && !h.checkConsumers(c, version) {
+		return
+	}
+
+	if result := h.db.Delete(version); result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionDeleteFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
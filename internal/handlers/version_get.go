@@ -0,0 +1,163 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+)
+
+// unlistedStatuses are the version statuses GetLatestVersion never returns.
+var unlistedStatuses = []models.VersionStatus{models.VersionDraft, models.VersionYanked}
+
+// GetVersion handles GET /services/:id/versions/:version endpoint.
+//
+// Retrieves a single version of a service, looked up either by its
+// numeric ID or by its version number, with the ranges of versions of
+// other services it requires.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Query Parameters:
+//   - showDeleted (bool): Include a soft-deleted version if true
+//
+// Returns:
+//
+//	200: VersionResponse - The version with its owning service_id and requirements
+//	400: Invalid service ID or version
+//	404: Version not found for this service
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/1/versions/2.0.0
+func (h *Handler) GetVersion(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	showDeleted := c.Query(constants.ShowDeleted) == constants.True
+	version, ok := h.findVersion(c, serviceID, ref, showDeleted)
+	if !ok {
+		return
+	}
+
+	requires, err := h.loadRequirements(version.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrRequirementsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, VersionResponse{Version: *version, Requires: requires})
+}
+
+// GetLatestVersion handles GET /services/:id/versions/latest endpoint.
+//
+// Resolves the version with the highest semantic version precedence,
+// so "1.10.0" is newer than "1.9.0" regardless of publication order.
+// Drafts and yanked versions are never returned.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//
+// Query Parameters:
+//   - excludePrerelease (bool): Ignore pre-release versions such as 2.0.0-rc.1
+//
+// Returns:
+//
+//	200: Version - The latest version
+//	400: Invalid service ID
+//	404: Service not found or it has no matching versions
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/1/versions/latest?excludePrerelease=true
+func (h *Handler) GetLatestVersion(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.findService(c, serviceID, false); !ok {
+		return
+	}
+
+	query := h.db.Where("service_id = ? AND status NOT IN ?", serviceID, unlistedStatuses)
+	if c.Query(constants.ExcludePrerelease) == constants.True {
+		query = query.Where("pre_release = ''")
+	}
+
+	var latest models.Version
+	result := query.Order(versionPrecedenceOrder(constants.DescSortOrder)).Limit(1).Find(&latest)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrVersionNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, latest)
+}
+
+// findVersion loads a version of a service by ID or number, writing a 404
+// or 500 response when it cannot be loaded. Soft-deleted versions are only
+// returned when unscoped is set.
+func (h *Handler) findVersion(c *gin.Context, serviceID uint64, ref validation.VersionRef, unscoped bool) (*models.Version, bool) {
+	query := h.db.Where("service_id = ?", serviceID)
+	if unscoped {
+		query = query.Unscoped()
+	}
+	if ref.Number != "" {
+		query = query.Where("number = ?", ref.Number)
+	} else {
+		query = query.Where("id = ?", ref.ID)
+	}
+
+	var version models.Version
+	result := query.First(&version)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, &constants.ServiceError{
+				Status:  constants.StatusNotFound,
+				Message: constants.ErrVersionNotFound,
+				Details: result.Error.Error(),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return nil, false
+	}
+
+	return &version, true
+}
@@ -0,0 +1,309 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/protoset"
+	"serviceCatalog/internal/validation"
+)
+
+// PutVersionProto handles PUT /services/:id/versions/:version/proto endpoint.
+//
+// Attaches a compiled protobuf FileDescriptorSet, as written by
+// "protoc --include_imports --descriptor_set_out", to a version, replacing
+// any previous one. Sets compiled without --include_imports are accepted;
+// fields of imported types are then compared by type name only.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Request Body: Binary FileDescriptorSet, at most 5 MiB
+//
+// Returns:
+//
+//	200: DescriptorSetResponse listing the files and services of the set
+//	400: Invalid service ID, version or unreadable body
+//	404: Version not found for this service
+//	413: Descriptor set is too large
+//	422: Not a valid FileDescriptorSet
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/1/versions/2.0.0/proto
+//	Content-Type: application/x-protobuf
+//	<output of protoc --descriptor_set_out>
+func (h *Handler) PutVersionProto(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	data, ok := readLimitedBody(c, constants.MaxDescriptorSetSize, constants.ErrDescriptorsTooLarge)
+	if !ok {
+		return
+	}
+
+	set, err := protoset.Parse(data)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrInvalidDescriptors,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	descriptors := models.VersionDescriptorSet{VersionID: version.ID, Data: data}
+	err = h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "version_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "updated_at"}),
+	}).Create(&descriptors).Error
+	if err == nil {
+		// Reload to report the creation time of a replaced set
+		err = h.db.Where("version_id = ?", version.ID).First(&descriptors).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDescriptorsUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, descriptorSetResponse(&descriptors, set))
+}
+
+// GetVersionProto handles GET /services/:id/versions/:version/proto endpoint.
+//
+// Describes the FileDescriptorSet of a version: the files it was compiled
+// from and the gRPC services they define, with the request and response
+// types of every method. With Accept: application/x-protobuf or
+// application/octet-stream the set is returned as uploaded instead, to
+// feed code generators or reflection tools.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Returns:
+//
+//	200: DescriptorSetResponse, or the binary set
+//	400: Invalid service ID or version
+//	404: Version not found or it has no descriptor set
+//	500: Database error or unreadable stored set
+//
+// Example:
+//
+//	GET /services/payment-gateway/versions/2.0.0/proto
+func (h *Handler) GetVersionProto(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	var descriptors models.VersionDescriptorSet
+	result := h.db.Where("version_id = ?", version.ID).Limit(1).Find(&descriptors)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDescriptorsFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrDescriptorsNotFound,
+		})
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, constants.ContentTypeProtobuf, constants.ContentTypeOctetStream) != gin.MIMEJSON {
+		c.Data(http.StatusOK, constants.ContentTypeProtobuf, descriptors.Data)
+		return
+	}
+
+	set, err := protoset.Parse(descriptors.Data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrDescriptorsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, descriptorSetResponse(&descriptors, set))
+}
+
+// CheckVersionProto handles GET /services/:id/versions/:version/proto/compatibility endpoint.
+//
+// Checks the FileDescriptorSet of a version for wire compatibility with
+// that of an earlier one, by default the closest released, deprecated or
+// end-of-life version before it that has a descriptor set. Messages are
+// matched by fully qualified name and fields by number. Removed services
+// and RPCs, changed RPC types or streaming, fields and enum values removed
+// without reserving their number, and changed field numbers, types or
+// cardinality are breaking; types that share a wire encoding, such as
+// int32 and int64 or string and bytes, are compatible. CI can fail a build
+// on "compatible": false.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Query Parameters:
+//   - against (string): Version ID or number to compare with instead of the previous published version
+//
+// Returns:
+//
+//	200: CompatibilityResponse; without a version to compare with, compatible with no changes
+//	400: Invalid service ID or version reference
+//	404: Version not found, or it or the against version has no descriptor set
+//	500: Database error or unreadable stored set
+//
+// Example:
+//
+//	GET /services/1/versions/2.0.0/proto/compatibility?against=1.4.0
+func (h *Handler) CheckVersionProto(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var againstRef *validation.VersionRef
+	if value := c.Query(constants.Against); value != "" {
+		parsed, validationErr := validation.ParseVersionRef(value)
+		if validationErr != nil {
+			c.JSON(validationErr.Status, validationErr)
+			return
+		}
+		againstRef = &parsed
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+	set, ok := h.requireDescriptorSet(c, version)
+	if !ok {
+		return
+	}
+
+	var against *models.Version
+	if againstRef != nil {
+		if against, ok = h.findVersion(c, serviceID, *againstRef, false); !ok {
+			return
+		}
+	} else {
+		var err error
+		if against, err = h.previousPublished(version, "version_descriptor_sets"); err != nil {
+			c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+				Status:  constants.StatusInternalServerError,
+				Message: constants.ErrCompatibilityFailed,
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	response := CompatibilityResponse{
+		ServiceID:  version.ServiceID,
+		Version:    version.Number,
+		Compatible: true,
+		Changes:    []protoset.Change{},
+	}
+	if against != nil {
+		againstSet, ok := h.requireDescriptorSet(c, against)
+		if !ok {
+			return
+		}
+		if changes := protoset.Compare(againstSet, set); changes != nil {
+			response.Changes = changes
+		}
+		response.Against = against.Number
+		response.Compatible = !protoset.HasBreaking(response.Changes)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// requireDescriptorSet loads and parses the descriptor set of a version,
+// writing a 404 response when it has none.
+func (h *Handler) requireDescriptorSet(c *gin.Context, version *models.Version) (*protoset.Set, bool) {
+	var descriptors models.VersionDescriptorSet
+	result := h.db.Where("version_id = ?", version.ID).Limit(1).Find(&descriptors)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrCompatibilityFailed,
+			Details: result.Error.Error(),
+		})
+		return nil, false
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrDescriptorsNotFound,
+			Details: "version " + version.Number + " has no descriptor set",
+		})
+		return nil, false
+	}
+
+	set, err := protoset.Parse(descriptors.Data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrCompatibilityFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return set, true
+}
+
+func descriptorSetResponse(descriptors *models.VersionDescriptorSet, set *protoset.Set) DescriptorSetResponse {
+	return DescriptorSetResponse{
+		VersionID: descriptors.VersionID,
+		Files:     set.Files(),
+		Services:  set.Services(),
+		CreatedAt: descriptors.CreatedAt,
+		UpdatedAt: descriptors.UpdatedAt,
+	}
+}
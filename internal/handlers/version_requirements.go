@@ -0,0 +1,327 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/semver"
+	"serviceCatalog/internal/slug"
+	"serviceCatalog/internal/validation"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetVersionRequirements handles GET /services/:id/versions/:version/requires endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Returns:
+//
+//	200: RequirementsResponse with the ranges the version needs
+//	400: Invalid service ID or version
+//	404: Version not found for this service
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/checkout/versions/3.1.0/requires
+func (h *Handler) GetVersionRequirements(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	h.writeRequirements(c, version.ID)
+}
+
+// ReplaceVersionRequirements handles PUT /services/:id/versions/:version/requires endpoint.
+//
+// Replaces all requirements of a version with those in the body;
+// requirements missing from the body are removed. Each maps a service ID
+// or slug to a semantic version range of it, such as ">=2.0.0 <3.0.0",
+// "^2.1" or "1.x || 2.x".
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Request Body: object of service IDs or slugs to version ranges
+//
+// Returns:
+//
+//	200: RequirementsResponse with the new requirements
+//	400: Invalid service ID, version or malformed body
+//	404: Version not found for this service
+//	422: Invalid range, unknown service or the version's own service
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/checkout/versions/3.1.0/requires
+//	{"payment-gateway": ">=2.0.0 <3.0.0", "inventory": "^1.4"}
+func (h *Handler) ReplaceVersionRequirements(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var req map[string]string
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	requirements, ok := h.parseRequirements(c, version.ServiceID, req)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version_id = ?", version.ID).Delete(&models.VersionRequirement{}).Error; err != nil {
+			return err
+		}
+		if len(requirements) == 0 {
+			return nil
+		}
+		for i := range requirements {
+			requirements[i].VersionID = version.ID
+		}
+		return tx.Create(&requirements).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrRequirementsUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.writeRequirements(c, version.ID)
+}
+
+// parseRequirements validates the requirements of a version of a service,
+// given as service IDs or slugs mapped to version ranges, and resolves the
+// services. A 422 response naming each invalid entry "requires.<key>" is
+// written and false returned when any is invalid.
+func (h *Handler) parseRequirements(c *gin.Context, serviceID uint, requires map[string]string) ([]models.VersionRequirement, bool) {
+	keys := make([]string, 0, len(requires))
+	for key := range requires {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fieldErrors []*constants.FieldError
+	if len(keys) > constants.MaxRequirements {
+		fieldErrors = append(fieldErrors, &constants.FieldError{
+			Field:   constants.Requires,
+			Message: fmt.Sprintf("must have at most %d entries", constants.MaxRequirements),
+		})
+		keys = nil
+	}
+	var ids []uint
+	var slugs []string
+	for _, key := range keys {
+		field := constants.Requires + "." + key
+		if len(requires[key]) > constants.MaxRangeLength {
+			fieldErrors = append(fieldErrors, &constants.FieldError{
+				Field:   field,
+				Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxRangeLength),
+			})
+		} else if _, err := semver.ParseConstraint(requires[key]); err != nil {
+			fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: err.Error()})
+		}
+		if id, err := strconv.ParseUint(key, 10, 64); err == nil && id > 0 {
+			ids = append(ids, uint(id))
+		} else if slug.Valid(key) {
+			slugs = append(slugs, key)
+		} else {
+			fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: "must be a service ID or slug"})
+		}
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+	if len(keys) == 0 {
+		return nil, true
+	}
+
+	var services []models.Service
+	if err := h.db.Select("id, slug").Where("id IN ? OR slug IN ?", ids, slugs).Find(&services).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServiceFetchFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	byKey := map[string]uint{}
+	for _, service := range services {
+		byKey[strconv.FormatUint(uint64(service.ID), 10)] = service.ID
+		byKey[service.Slug] = service.ID
+	}
+
+	var requirements []models.VersionRequirement
+	required := map[uint]string{}
+	for _, key := range keys {
+		field := constants.Requires + "." + key
+		id, ok := byKey[key]
+		switch {
+		case !ok:
+			fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: constants.ErrServiceNotFound})
+		case id == serviceID:
+			fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: "a version cannot require its own service"})
+		case required[id] != "":
+			fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: "same service as " + required[id]})
+		default:
+			required[id] = key
+			requirements = append(requirements, models.VersionRequirement{
+				DependsOnID: id,
+				Range:       strings.TrimSpace(requires[key]),
+			})
+		}
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+	return requirements, true
+}
+
+// loadRequirements returns the requirements of a version sorted by
+// service slug.
+func (h *Handler) loadRequirements(versionID uint) ([]RequirementResponse, error) {
+	requires := []RequirementResponse{}
+	err := h.db.Table("version_requirements").
+		Select("version_requirements.depends_on_id AS service_id, services.slug AS service, version_requirements.range").
+		Joins("JOIN services ON services.id = version_requirements.depends_on_id").
+		Where("version_requirements.version_id = ?", versionID).
+		Order("services.slug").
+		Scan(&requires).Error
+	return requires, err
+}
+
+// writeRequirements responds with the requirements of a version.
+func (h *Handler) writeRequirements(c *gin.Context, versionID uint) {
+	requires, err := h.loadRequirements(versionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrRequirementsFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RequirementsResponse{VersionID: versionID, Requires: requires})
+}
+
+// checkConsumers reports whether a version may be deprecated, yanked or
+// deleted because no released version of another service still needs it:
+// every released consumer whose range the version satisfies must be
+// satisfied by another released version of the service too. Otherwise a
+// 409 response naming the consumers, or a 500 response, is written.
+func (h *Handler) checkConsumers(c *gin.Context, version *models.Version) bool {
+	var consumers []struct {
+		Service string
+		Number  string
+		Range   string
+	}
+	err := h.db.Table("version_requirements").
+		Select("services.slug AS service, versions.number, version_requirements.range").
+		Joins("JOIN versions ON versions.id = version_requirements.version_id").
+		Joins("JOIN services ON services.id = versions.service_id").
+		Where("version_requirements.depends_on_id = ? AND versions.status = ?", version.ServiceID, models.VersionReleased).
+		Where("versions.deleted_at IS NULL AND services.deleted_at IS NULL").
+		Order("services.slug, " + versionPrecedenceOrder(constants.DefaultSortOrder)).
+		Scan(&consumers).Error
+	var others []models.Version
+	if err == nil {
+		err = h.db.Where("service_id = ? AND status = ? AND id <> ?", version.ServiceID, models.VersionReleased, version.ID).
+			Find(&others).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrRequirementsFetchFailed,
+			Details: err.Error(),
+		})
+		return false
+	}
+
+	// Numbers that are not valid semantic versions predate validation and
+	// are checked as 0.0.0, the components the migration leaves them at
+	parsed, _ := semver.Parse(version.Number)
+	var blocked []string
+	for _, consumer := range consumers {
+		constraint, err := semver.ParseConstraint(consumer.Range)
+		if err != nil || !constraint.Check(parsed) {
+			continue
+		}
+		if !anySatisfies(constraint, others) {
+			blocked = append(blocked, fmt.Sprintf("%s@%s (%s)", consumer.Service, consumer.Number, consumer.Range))
+		}
+	}
+	if len(blocked) > 0 {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrVersionRequired,
+			Details: fmt.Sprintf("required by %s and no other released version satisfies them; release one or pass force=true",
+				strings.Join(blocked, ", ")),
+		})
+		return false
+	}
+	return true
+}
+
+// mayBeRequired reports whether consumers may rely on a version in status,
+// so withdrawing it is guarded by checkConsumers. Drafts and yanked
+// versions were never meant to be used, and end-of-life ones are past
+// their announced end.
+func mayBeRequired(status models.VersionStatus) bool {
+	return status == models.VersionReleased || status == models.VersionDeprecated
+}
+
+// anySatisfies reports whether any of versions satisfies constraint.
+func anySatisfies(constraint *semver.Constraint, versions []models.Version) bool {
+	for _, version := range versions {
+		if parsed, err := semver.Parse(version.Number); err == nil && constraint.Check(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/resolver"
+	"serviceCatalog/internal/semver"
+	"serviceCatalog/internal/slug"
+	"serviceCatalog/internal/validation"
+	"strconv"
+	"strings"
+)
+
+// ResolveVersions handles GET /resolve endpoint.
+//
+// Picks one released version of each listed service, and of every service
+// the picked versions require, such that every picked version's
+// requirements are satisfied by the others. Newer versions are preferred,
+// services listed first most. When no such set exists the conflict is
+// explained: the service no version could be picked for, the ranges
+// required of it and why each of its released versions was rejected.
+//
+// Query Parameters:
+//   - services (string): Comma-separated service IDs or slugs, required
+//   - excludePrerelease (bool): Ignore pre-release versions such as 2.0.0-rc.1
+//
+// Returns:
+//
+//	200: ResolveResponse, with resolved false and a conflict when there is no solution
+//	404: A listed service was not found
+//	422: Missing or invalid services, or too many combinations to try
+//	500: Database error
+//
+// Example:
+//
+//	GET /resolve?services=checkout,payment-gateway,inventory
+func (h *Handler) ResolveVersions(c *gin.Context) {
+	refs, ok := parseServiceList(c, c.Query(constants.Services))
+	if !ok {
+		return
+	}
+
+	serviceIDs, ok := h.lookupServices(c, refs)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(c)
+	defer cancel()
+
+	excludePrerelease := c.Query(constants.ExcludePrerelease) == constants.True
+	result, err := resolver.Resolve(serviceIDs, func(serviceID uint) (*resolver.Service, error) {
+		return h.resolverService(ctx, serviceID, excludePrerelease)
+	}, constants.MaxResolveSteps)
+	if err != nil {
+		if errors.Is(err, resolver.ErrTooComplex) {
+			c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+				Status:  constants.StatusUnprocessableEntity,
+				Message: constants.ErrResolveTooComplex,
+				Details: fmt.Sprintf("gave up after trying %d versions", constants.MaxResolveSteps),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrResolveFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	requested := map[uint]bool{}
+	for _, id := range serviceIDs {
+		requested[id] = true
+	}
+	response := ResolveResponse{Resolved: result.Conflict == nil, Versions: []ResolvedVersion{}}
+	for _, candidate := range result.Versions {
+		response.Versions = append(response.Versions, ResolvedVersion{
+			ServiceID: candidate.ServiceID,
+			Service:   candidate.Service,
+			VersionID: candidate.VersionID,
+			Version:   candidate.Number,
+			Requested: requested[candidate.ServiceID],
+		})
+	}
+	if conflict := result.Conflict; conflict != nil {
+		response.Conflict = &ResolveConflict{
+			ServiceID:  conflict.ServiceID,
+			Service:    conflict.Service,
+			Message:    conflict.Message(),
+			RequiredBy: []ConflictRequirement{},
+			Rejected:   []RejectedVersion{},
+		}
+		for _, demand := range conflict.Demands {
+			response.Conflict.RequiredBy = append(response.Conflict.RequiredBy, ConflictRequirement{
+				Service: demand.By.Service,
+				Version: demand.By.Number,
+				Range:   demand.Range.String(),
+			})
+		}
+		for _, rejection := range conflict.Rejected {
+			response.Conflict.Rejected = append(response.Conflict.Rejected, RejectedVersion{
+				Version: rejection.Candidate.Number,
+				Reason:  rejection.Reason,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseServiceList splits a comma-separated list of service IDs and slugs,
+// writing a 422 response when it is empty, too long or has invalid entries.
+func parseServiceList(c *gin.Context, value string) ([]validation.ServiceRef, bool) {
+	var refs []validation.ServiceRef
+	var fieldErr *constants.FieldError
+	for _, value := range strings.Split(value, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if id, err := strconv.ParseUint(value, 10, 64); err == nil && id > 0 {
+			refs = append(refs, validation.ServiceRef{ID: id})
+		} else if slug.Valid(value) {
+			refs = append(refs, validation.ServiceRef{Slug: value})
+		} else if fieldErr == nil {
+			fieldErr = &constants.FieldError{Field: constants.Services, Message: value + " is not a service ID or slug"}
+		}
+	}
+	switch {
+	case len(refs) == 0 && fieldErr == nil:
+		fieldErr = &constants.FieldError{Field: constants.Services, Message: constants.ErrFieldRequired}
+	case len(refs) > constants.MaxResolveServices:
+		fieldErr = &constants.FieldError{
+			Field:   constants.Services,
+			Message: fmt.Sprintf("must list at most %d services", constants.MaxResolveServices),
+		}
+	}
+	if validationErr := validation.NewValidationError(fieldErr); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return nil, false
+	}
+	return refs, true
+}
+
+// lookupServices returns the IDs of the referenced services in order,
+// writing a 404 response naming any that do not exist.
+func (h *Handler) lookupServices(c *gin.Context, refs []validation.ServiceRef) ([]uint, bool) {
+	var ids []uint64
+	var slugs []string
+	for _, ref := range refs {
+		if ref.Slug != "" {
+			slugs = append(slugs, ref.Slug)
+		} else {
+			ids = append(ids, ref.ID)
+		}
+	}
+
+	var services []models.Service
+	if err := h.db.Select("id, slug").
+		Where("id IN ? OR slug IN ?", ids, slugs).
+		Find(&services).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrServicesFetchFailed,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	byRef := map[validation.ServiceRef]uint{}
+	for _, service := range services {
+		byRef[validation.ServiceRef{ID: uint64(service.ID)}] = service.ID
+		byRef[validation.ServiceRef{Slug: service.Slug}] = service.ID
+	}
+
+	var serviceIDs []uint
+	var missing []string
+	for _, ref := range refs {
+		if id, ok := byRef[ref]; ok {
+			serviceIDs = append(serviceIDs, id)
+		} else if ref.Slug != "" {
+			missing = append(missing, ref.Slug)
+		} else {
+			missing = append(missing, strconv.FormatUint(ref.ID, 10))
+		}
+	}
+	if len(missing) > 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrServiceNotFound,
+			Details: strings.Join(missing, ", "),
+		})
+		return nil, false
+	}
+	return serviceIDs, true
+}
+
+// resolverService loads a service with its released versions, newest
+// first, and their requirements. Soft-deleted services have no versions to
+// pick from.
+func (h *Handler) resolverService(ctx context.Context, serviceID uint, excludePrerelease bool) (*resolver.Service, error) {
+	db := h.db.WithContext(ctx)
+
+	var service models.Service
+	result := db.Unscoped().Select("id, slug, deleted_at").Where("id = ?", serviceID).Limit(1).Find(&service)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	resolved := &resolver.Service{ID: serviceID, Name: service.Slug}
+	if result.RowsAffected == 0 || service.DeletedAt.Valid {
+		if resolved.Name == "" {
+			resolved.Name = strconv.FormatUint(uint64(serviceID), 10)
+		}
+		return resolved, nil
+	}
+
+	query := db.Where("service_id = ? AND status = ?", serviceID, models.VersionReleased)
+	if excludePrerelease {
+		query = query.Where("pre_release = ''")
+	}
+	var versions []models.Version
+	if err := query.Order(versionPrecedenceOrder(constants.DescSortOrder)).Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return resolved, nil
+	}
+
+	versionIDs := make([]uint, 0, len(versions))
+	for _, version := range versions {
+		versionIDs = append(versionIDs, version.ID)
+	}
+	var requirements []struct {
+		VersionID   uint
+		DependsOnID uint
+		Service     string
+		Range       string
+	}
+	err := db.Table("version_requirements").
+		Select("version_requirements.version_id, version_requirements.depends_on_id, services.slug AS service, version_requirements.range").
+		Joins("JOIN services ON services.id = version_requirements.depends_on_id").
+		Where("version_requirements.version_id IN ?", versionIDs).
+		Order("services.slug").
+		Scan(&requirements).Error
+	if err != nil {
+		return nil, err
+	}
+	requires := map[uint][]resolver.Requirement{}
+	for _, requirement := range requirements {
+		constraint, err := semver.ParseConstraint(requirement.Range)
+		if err != nil {
+			return nil, err
+		}
+		requires[requirement.VersionID] = append(requires[requirement.VersionID], resolver.Requirement{
+			ServiceID: requirement.DependsOnID,
+			Service:   requirement.Service,
+			Range:     constraint,
+		})
+	}
+
+	for _, version := range versions {
+		parsed, err := semver.Parse(version.Number)
+		if err != nil {
+			continue
+		}
+		resolved.Candidates = append(resolved.Candidates, resolver.Candidate{
+			ServiceID: serviceID,
+			Service:   service.Slug,
+			VersionID: version.ID,
+			Number:    version.Number,
+			Version:   parsed,
+			Requires:  requires[version.ID],
+		})
+	}
+	return resolved, nil
+}
@@ -0,0 +1,203 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/sbom"
+	"serviceCatalog/internal/validation"
+)
+
+// sbomBatchSize is the number of components inserted per statement.
+const sbomBatchSize = 500
+
+// PutVersionSBOM handles PUT /services/:id/versions/:version/sbom endpoint.
+//
+// Attaches a software bill of materials, in CycloneDX JSON or SPDX JSON,
+// to a version, replacing any previous one and its components. The
+// components are normalized to a package URL, name, version and license
+// so GET /components finds them whatever the format; components nested in
+// others are included, the subject of the document is not.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Request Body: CycloneDX or SPDX JSON document, at most 20 MiB
+//
+// Returns:
+//
+//	200: SBOMResponse with the normalized components
+//	400: Invalid service ID, version or unreadable body
+//	404: Version not found for this service
+//	413: Document is too large
+//	422: Not a CycloneDX or SPDX JSON document
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/payment-gateway/versions/2.0.0/sbom
+//	{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": [...]}
+func (h *Handler) PutVersionSBOM(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	data, ok := readLimitedBody(c, constants.MaxSBOMSize, constants.ErrSBOMTooLarge)
+	if !ok {
+		return
+	}
+
+	doc, err := sbom.Parse(data)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrInvalidSBOM,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	components := make([]models.SBOMComponent, 0, len(doc.Components))
+	for _, component := range doc.Components {
+		row := models.SBOMComponent{
+			VersionID: version.ID,
+			PURL:      component.PURL,
+			Name:      component.Name,
+			Version:   component.Version,
+			License:   component.License,
+		}
+		if purl, err := sbom.ParsePURL(component.PURL); err == nil {
+			row.PURLType = purl.Type
+			row.PURLNamespace = purl.Namespace
+			row.PURLName = purl.Name
+		}
+		components = append(components, row)
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		document := models.VersionSBOM{
+			VersionID:   version.ID,
+			Format:      string(doc.Format),
+			SpecVersion: doc.SpecVersion,
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "version_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"format", "spec_version", "updated_at"}),
+		}).Create(&document).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("version_id = ?", version.ID).Delete(&models.SBOMComponent{}).Error; err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&components, sbomBatchSize).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSBOMUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.writeSBOM(c, version)
+}
+
+// GetVersionSBOM handles GET /services/:id/versions/:version/sbom endpoint.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Returns:
+//
+//	200: SBOMResponse with the normalized components
+//	400: Invalid service ID or version
+//	404: Version not found or it has no SBOM
+//	500: Database error
+//
+// Example:
+//
+//	GET /services/payment-gateway/versions/2.0.0/sbom
+func (h *Handler) GetVersionSBOM(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	h.writeSBOM(c, version)
+}
+
+// writeSBOM responds with the SBOM of a version and its components, or
+// 404 when it has none.
+func (h *Handler) writeSBOM(c *gin.Context, version *models.Version) {
+	var document models.VersionSBOM
+	result := h.db.Where("version_id = ?", version.ID).Limit(1).Find(&document)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSBOMFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrSBOMNotFound,
+			Details: "version " + version.Number + " has no SBOM",
+		})
+		return
+	}
+
+	components := []models.SBOMComponent{}
+	if err := h.db.Where("version_id = ?", version.ID).Order("purl, name, version, id").Find(&components).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSBOMFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SBOMResponse{
+		VersionID:   document.VersionID,
+		Format:      document.Format,
+		SpecVersion: document.SpecVersion,
+		Components:  components,
+		CreatedAt:   document.CreatedAt,
+		UpdatedAt:   document.UpdatedAt,
+	})
+}
@@ -0,0 +1,273 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+	"io"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/openapi"
+	"serviceCatalog/internal/validation"
+)
+
+// latestSpecCondition is an SQL condition that holds when the latest
+// version of a service, as resolved by GetLatestVersion without
+// excludePrerelease, has a specification. The %s verb is replaced by the
+// expression of the service ID, and unlistedStatuses must be bound as the
+// condition's only parameter.
+const latestSpecCondition = `EXISTS (SELECT 1 FROM version_specs WHERE version_specs.version_id = (
+	SELECT latest.id FROM versions latest
+	WHERE latest.service_id = %s AND latest.deleted_at IS NULL AND latest.status NOT IN ?
+	ORDER BY latest.major DESC, latest.minor DESC, latest.patch DESC, latest.pre_release_key COLLATE "C" DESC
+	LIMIT 1))`
+
+// PutVersionSpec handles PUT /services/:id/versions/:version/spec endpoint.
+//
+// Attaches an OpenAPI 3.x document, in YAML or JSON, to a version,
+// replacing any previous one. Bodies starting with "{" are read as JSON.
+// The document is checked structurally (required fields, paths,
+// operations, parameters, responses, components and local references)
+// and stored as uploaded; schemas are not validated.
+//
+// With rejectBreaking=true, the document of a version that is not a draft
+// is compared with the specification of the closest earlier published
+// version, and refused when it has breaking changes but the major version
+// number did not increase. Drafts are checked when they are released
+// instead, see TransitionVersionStatus.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Query Parameters:
+//   - rejectBreaking (bool): Refuse breaking API changes for published versions
+//
+// Request Body: OpenAPI document, at most 5 MiB
+//
+// Returns:
+//
+//	200: VersionSpec metadata of the stored document
+//	400: Invalid service ID, version or unreadable body
+//	404: Version not found for this service
+//	409: Breaking changes with rejectBreaking set
+//	413: Document is too large
+//	422: Not YAML or JSON, one field error per violation named "spec.<path>", or too complex to compare
+//	500: Database error
+//
+// Example:
+//
+//	PUT /services/1/versions/2.0.0/spec
+//	Content-Type: application/yaml
+//	openapi: 3.0.3
+//	info: {title: Payments, version: 2.0.0}
+//	paths: {}
+func (h *Handler) PutVersionSpec(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	data, ok := readLimitedBody(c, constants.MaxSpecSize, constants.ErrSpecTooLarge)
+	if !ok {
+		return
+	}
+
+	doc, err := openapi.Parse(data)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, &constants.ServiceError{
+			Status:  constants.StatusUnprocessableEntity,
+			Message: constants.ErrInvalidSpec,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var fieldErrors []*constants.FieldError
+	for _, violation := range doc.Validate() {
+		field := constants.Spec
+		if violation.Path != "" {
+			field += "." + violation.Path
+		}
+		fieldErrors = append(fieldErrors, &constants.FieldError{Field: field, Message: violation.Message})
+	}
+	if validationErr := validation.NewValidationError(fieldErrors...); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	if version.Status != models.VersionDraft && c.Query(constants.RejectBreaking) == constants.True &&
+		!h.checkBreakingDocument(c, version, doc) {
+		return
+	}
+
+	title, _ := doc.Info()
+	spec := models.VersionSpec{
+		VersionID: version.ID,
+		OpenAPI:   doc.Version,
+		Title:     title,
+		Format:    string(doc.Format),
+		Document:  string(data),
+	}
+	err = h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "version_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"openapi", "title", "format", "document", "updated_at"}),
+	}).Create(&spec).Error
+	if err == nil {
+		// Reload to report the creation time of a replaced document
+		err = h.db.Where("version_id = ?", version.ID).First(&spec).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecUpdateFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, spec)
+}
+
+// GetVersionSpec handles GET /services/:id/versions/:version/spec endpoint.
+//
+// Returns the OpenAPI document of a version. The format follows the Accept
+// header: application/json for JSON, application/yaml or
+// application/x-yaml for YAML. Without a matching Accept header the
+// document is returned in the format it was uploaded in, byte for byte.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Returns:
+//
+//	200: OpenAPI document as JSON or YAML
+//	400: Invalid service ID or version
+//	404: Version not found or it has no specification
+//	500: Database or conversion error
+//
+// Example:
+//
+//	GET /services/payment-gateway/versions/2.0.0/spec
+//	Accept: application/json
+func (h *Handler) GetVersionSpec(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	var spec models.VersionSpec
+	result := h.db.Where("version_id = ?", version.ID).Limit(1).Find(&spec)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecFetchFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, &constants.ServiceError{
+			Status:  constants.StatusNotFound,
+			Message: constants.ErrSpecNotFound,
+		})
+		return
+	}
+
+	format := negotiateSpecFormat(c, openapi.Format(spec.Format))
+	doc, err := openapi.Parse([]byte(spec.Document))
+	var data []byte
+	if err == nil {
+		data, err = doc.Marshal(format)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrSpecFetchFailed,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	contentType := gin.MIMEJSON
+	if format == openapi.FormatYAML {
+		contentType = constants.ContentTypeYAML
+	}
+	c.Data(http.StatusOK, contentType+"; charset=utf-8", data)
+}
+
+// readLimitedBody reads the request body, writing a 413 response with
+// message and returning false when it is longer than limit bytes.
+func readLimitedBody(c *gin.Context, limit int64, message string) ([]byte, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, &constants.ServiceError{
+				Status:  constants.StatusPayloadTooLarge,
+				Message: message,
+				Details: fmt.Sprintf("must be at most %d bytes", limit),
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return data, true
+}
+
+// negotiateSpecFormat picks the format of a specification from the Accept
+// header, preferring stored, the format it was uploaded in.
+func negotiateSpecFormat(c *gin.Context, stored openapi.Format) openapi.Format {
+	offered := []string{gin.MIMEJSON, constants.ContentTypeYAML, gin.MIMEYAML}
+	if stored == openapi.FormatYAML {
+		offered = []string{constants.ContentTypeYAML, gin.MIMEYAML, gin.MIMEJSON}
+	}
+
+	switch c.NegotiateFormat(offered...) {
+	case gin.MIMEJSON:
+		return openapi.FormatJSON
+	case constants.ContentTypeYAML, gin.MIMEYAML:
+		return openapi.FormatYAML
+	default:
+		return stored
+	}
+}
+
+// latestVersionHasSpec reports whether the latest version of a service has
+// a specification, see latestSpecCondition.
+func (h *Handler) latestVersionHasSpec(serviceID uint) (bool, error) {
+	var hasSpec bool
+	err := h.db.Raw("SELECT "+fmt.Sprintf(latestSpecCondition, "?"), serviceID, unlistedStatuses).Scan(&hasSpec).Error
+	return hasSpec, err
+}
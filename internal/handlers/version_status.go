@@ -0,0 +1,226 @@
+// Package handlers implements HTTP handlers for service catalog CRUD operations.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/validation"
+	"strings"
+	"time"
+)
+
+// errStatusChanged aborts a status transition whose version left the
+// expected status while the transition was being written.
+var errStatusChanged = errors.New("version status changed concurrently")
+
+// TransitionVersionStatus handles POST /services/:id/versions/:version/status endpoint.
+//
+// Moves a version to another release status. Allowed transitions are:
+//
+//	draft       -> released
+//	released    -> deprecated, yanked
+//	deprecated  -> released, end-of-life, yanked
+//	end-of-life -> (none)
+//	yanked      -> (none)
+//
+// Deprecating a version sets its deprecation date, today unless given, and
+// optionally the planned end-of-life date. Moving it to end-of-life sets
+// the end-of-life date, today unless given. Releasing a deprecated version
+// again clears both dates.
+//
+// Publishing a draft with rejectBreaking=true compares its OpenAPI
+// specification with that of the closest earlier published version that
+// has one, and refuses breaking changes unless the major version number
+// increased. Only draft -> released is checked here; versions created as
+// released are checked when their specification is uploaded with
+// rejectBreaking=true, see PutVersionSpec.
+//
+// Deprecating or yanking a released or deprecated version is refused
+// while a released version of another service requires a range the
+// version satisfies and no other released version of the service does,
+// unless force=true is passed.
+//
+// URL Parameters:
+//   - id (string): Service ID or slug
+//   - version (string): Version ID (e.g. 3) or version number (e.g. 2.0.0)
+//
+// Query Parameters:
+//   - rejectBreaking (bool): Refuse to publish breaking API changes
+//   - force (bool): Deprecate or yank even when released consumers still require the version
+//
+// Request Body:
+//   - status (string): Target status, required
+//   - deprecation_date (RFC 3339): Optional, only when deprecating
+//   - end_of_life_date (RFC 3339): Optional, only when deprecating or
+//     moving to end-of-life; not before the deprecation date
+//
+// Returns:
+//
+//	200: Version after the transition
+//	400: Invalid service ID, version or malformed body
+//	404: Version not found for this service
+//	409: Illegal transition, breaking changes with rejectBreaking set, or still required
+//	422: Field validation failed, or specifications too complex to compare
+//	500: Database error
+//
+// Example:
+//
+//	POST /services/1/versions/1.4.0/status
+//	{"status": "deprecated", "end_of_life_date": "2025-06-30T00:00:00Z"}
+func (h *Handler) TransitionVersionStatus(c *gin.Context) {
+	serviceID, ok := h.resolveServiceID(c)
+	if !ok {
+		return
+	}
+
+	ref, validationErr := validation.ValidateVersionRef(c)
+	if validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	var req VersionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidBody,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	to := models.VersionStatus(req.Status)
+	if validationErr := validation.NewValidationError(
+		validation.ValidateVersionStatus(req.Status),
+		validateStatusDates(to, req),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	version, ok := h.findVersion(c, serviceID, ref, false)
+	if !ok {
+		return
+	}
+
+	from := version.Status
+	if !from.CanTransitionTo(to) {
+		c.JSON(http.StatusConflict, &constants.ServiceError{
+			Status:  constants.StatusConflict,
+			Message: constants.ErrIllegalVersionState,
+			Details: fmt.Sprintf("cannot move from %s to %s", from, to),
+		})
+		return
+	}
+
+	publishing := from == models.VersionDraft && to == models.VersionReleased
+	if publishing && c.Query(constants.RejectBreaking) == constants.True && !h.checkBreakingChanges(c, version) {
+		return
+	}
+
+	withdrawing := to == models.VersionDeprecated || to == models.VersionYanked
+	if withdrawing && mayBeRequired(from) && c.Query(constants.Force) != constants.True && !h.checkConsumers(c, version) {
+		return
+	}
+
+	deprecation, endOfLife := statusDates(version, to, req, time.Now().UTC())
+	if validationErr := validation.NewValidationError(
+		validation.ValidateEndOfLifeDate(deprecation, endOfLife),
+	); validationErr != nil {
+		c.JSON(validationErr.Status, validationErr)
+		return
+	}
+
+	// Only move the version if it is still in the status that was checked
+	result := h.db.Model(&models.Version{}).
+		Where("id = ? AND status = ?", version.ID, from).
+		UpdateColumns(map[string]interface{}{
+			"status":           to,
+			"deprecation_date": deprecation,
+			"end_of_life_date": endOfLife,
+		})
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = errStatusChanged
+	}
+	if result.Error != nil {
+		if errors.Is(result.Error, errStatusChanged) {
+			c.JSON(http.StatusConflict, &constants.ServiceError{
+				Status:  constants.StatusConflict,
+				Message: constants.ErrIllegalVersionState,
+				Details: result.Error.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &constants.ServiceError{
+			Status:  constants.StatusInternalServerError,
+			Message: constants.ErrVersionUpdateFailed,
+			Details: result.Error.Error(),
+		})
+		return
+	}
+
+	version.Status = to
+	version.DeprecationDate = deprecation
+	version.EndOfLifeDate = endOfLife
+	c.JSON(http.StatusOK, version)
+}
+
+// validateStatusDates rejects dates that do not apply to the target status.
+func validateStatusDates(to models.VersionStatus, req VersionStatusRequest) *constants.FieldError {
+	if req.DeprecationDate != nil && to != models.VersionDeprecated {
+		return &constants.FieldError{Field: constants.DeprecationDate, Message: constants.ErrDateNotApplicable}
+	}
+	if req.EndOfLifeDate != nil && to != models.VersionDeprecated && to != models.VersionEndOfLife {
+		return &constants.FieldError{Field: constants.EndOfLifeDate, Message: constants.ErrDateNotApplicable}
+	}
+	return nil
+}
+
+// statusDates returns the deprecation and end-of-life dates of a version
+// after it moves to status to, defaulting the date of the transition
+// itself to now.
+func statusDates(version *models.Version, to models.VersionStatus, req VersionStatusRequest, now time.Time) (*time.Time, *time.Time) {
+	deprecation, endOfLife := version.DeprecationDate, version.EndOfLifeDate
+	switch to {
+	case models.VersionReleased:
+		deprecation, endOfLife = nil, nil
+	case models.VersionDeprecated:
+		deprecation = &now
+		if req.DeprecationDate != nil {
+			deprecation = req.DeprecationDate
+		}
+		if req.EndOfLifeDate != nil {
+			endOfLife = req.EndOfLifeDate
+		}
+	case models.VersionEndOfLife:
+		endOfLife = &now
+		if req.EndOfLifeDate != nil {
+			endOfLife = req.EndOfLifeDate
+		}
+	}
+	return deprecation, endOfLife
+}
+
+// parseVersionStatusFilter splits a comma separated list of version
+// statuses, such as "released,deprecated", writing a 400 response and
+// returning false when a status is unknown.
+func parseVersionStatusFilter(c *gin.Context, value string) ([]string, bool) {
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		status = strings.TrimSpace(status)
+		if !models.VersionStatus(status).Valid() {
+			c.JSON(http.StatusBadRequest, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrBadRequest,
+				Details: constants.Status + " " + fmt.Sprintf(constants.ErrFieldNotOneOf, validation.VersionStatusNames()),
+			})
+			return nil, false
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, true
+}
@@ -0,0 +1,60 @@
+// Package labels validates Kubernetes-style service labels such as
+// "tier=critical" and parses label selectors used to query them.
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxNameLength is the maximum length of a label value and of the
+	// name part of a key.
+	MaxNameLength = 63
+	// MaxPrefixLength is the maximum length of the optional DNS prefix of
+	// a key, e.g. "example.com" in "example.com/team".
+	MaxPrefixLength = 253
+)
+
+var ErrInvalidLabel = errors.New("invalid label")
+
+var (
+	namePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateKey checks that key is a qualified name: an optional DNS
+// subdomain prefix and "/", followed by a name of at most 63 characters
+// made of alphanumerics, '-', '_' and '.', starting and ending with an
+// alphanumeric character.
+func ValidateKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if prefix == "" || len(prefix) > MaxPrefixLength || !prefixPattern.MatchString(prefix) {
+			return fmt.Errorf("%w: key prefix %q must be a lowercase DNS subdomain of at most %d characters",
+				ErrInvalidLabel, prefix, MaxPrefixLength)
+		}
+	}
+	if name == "" || len(name) > MaxNameLength || !namePattern.MatchString(name) {
+		return fmt.Errorf("%w: key name %q must be at most %d alphanumerics, '-', '_' or '.' and start and end with an alphanumeric",
+			ErrInvalidLabel, name, MaxNameLength)
+	}
+	return nil
+}
+
+// ValidateValue checks that value is empty or follows the rules of a
+// key name.
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > MaxNameLength || !namePattern.MatchString(value) {
+		return fmt.Errorf("%w: value %q must be at most %d alphanumerics, '-', '_' or '.' and start and end with an alphanumeric",
+			ErrInvalidLabel, value, MaxNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package labels
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"tier", "app.kubernetes.io/name", "Team_Name", "a"} {
+		assert.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "-tier", "tier-", "/tier", "Example.com/tier", "tier/", "has space", strings.Repeat("a", 64)} {
+		err := ValidateKey(key)
+		assert.True(t, errors.Is(err, ErrInvalidLabel), key)
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	for _, value := range []string{"", "critical", "1.2.3", "payments_eu"} {
+		assert.NoError(t, ValidateValue(value), value)
+	}
+	for _, value := range []string{"crit ical", "-critical", "a/b", strings.Repeat("a", 64)} {
+		assert.Error(t, ValidateValue(value), value)
+	}
+}
@@ -0,0 +1,263 @@
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSelector = errors.New("invalid label selector")
+
+// Operator is the operator of a selector requirement.
+type Operator string
+
+const (
+	OpEquals       Operator = "="
+	OpNotEquals    Operator = "!="
+	OpIn           Operator = "in"
+	OpNotIn        Operator = "notin"
+	OpExists       Operator = "exists"
+	OpDoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition of a selector, e.g. "tier=critical"
+// or "domain in (payments,billing)". Values is empty for OpExists and
+// OpDoesNotExist and holds exactly one value for OpEquals and OpNotEquals.
+//
+// As in Kubernetes, OpNotEquals and OpNotIn also match services that do
+// not carry the key at all.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector is a list of requirements that must all match.
+type Selector []Requirement
+
+// ParseSelector parses a comma separated list of requirements:
+//
+//	tier=critical         key equals value ("==" is accepted as well)
+//	language!=java        key is missing or has another value
+//	domain in (a,b)       key has one of the values
+//	domain notin (a,b)    key is missing or has none of the values
+//	tier                  key is present
+//	!tier                 key is missing
+//
+// Keys and values are validated with ValidateKey and ValidateValue.
+// An empty string parses to an empty selector.
+func ParseSelector(s string) (Selector, error) {
+	p := &parser{input: s, tokens: lex(s)}
+	if p.peek().kind == tokenEnd {
+		return nil, nil
+	}
+
+	var selector Selector
+	for {
+		requirement, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, requirement)
+
+		switch t := p.next(); t.kind {
+		case tokenEnd:
+			return selector, nil
+		case tokenComma:
+		default:
+			return nil, p.errorf("expected ',' but found %q", t.text)
+		}
+	}
+}
+
+// String returns the canonical form of the selector.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		switch r.Operator {
+		case OpExists:
+			parts = append(parts, r.Key)
+		case OpDoesNotExist:
+			parts = append(parts, "!"+r.Key)
+		case OpIn, OpNotIn:
+			parts = append(parts, fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ",")))
+		default:
+			parts = append(parts, r.Key+string(r.Operator)+r.Values[0])
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenNot
+	tokenEquals
+	tokenNotEquals
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lex splits a selector into tokens. Identifiers are runs of characters
+// that are neither whitespace nor one of "!=(),".
+func lex(s string) []token {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenClose, ")"})
+			i++
+		case strings.HasPrefix(s[i:], "!="):
+			tokens = append(tokens, token{tokenNotEquals, "!="})
+			i += 2
+		case c == '!':
+			tokens = append(tokens, token{tokenNot, "!"})
+			i++
+		case strings.HasPrefix(s[i:], "=="):
+			tokens = append(tokens, token{tokenEquals, "=="})
+			i += 2
+		case c == '=':
+			tokens = append(tokens, token{tokenEquals, "="})
+			i++
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t!=(),", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdentifier, s[i:end]})
+			i = end
+		}
+	}
+	return append(tokens, token{tokenEnd, "end of selector"})
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidSelector, p.input, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) requirement() (Requirement, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		key, err := p.key()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: OpDoesNotExist}, nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	switch t := p.peek(); {
+	case t.kind == tokenEnd || t.kind == tokenComma:
+		return Requirement{Key: key, Operator: OpExists}, nil
+	case t.kind == tokenEquals || t.kind == tokenNotEquals:
+		p.next()
+		operator := OpEquals
+		if t.kind == tokenNotEquals {
+			operator = OpNotEquals
+		}
+		value, err := p.value(true)
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: operator, Values: []string{value}}, nil
+	case t.kind == tokenIdentifier && (t.text == string(OpIn) || t.text == string(OpNotIn)):
+		p.next()
+		values, err := p.values()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: Operator(t.text), Values: values}, nil
+	default:
+		return Requirement{}, p.errorf("expected an operator after %q but found %q", key, t.text)
+	}
+}
+
+func (p *parser) key() (string, error) {
+	t := p.next()
+	if t.kind != tokenIdentifier {
+		return "", p.errorf("expected a label key but found %q", t.text)
+	}
+	if err := ValidateKey(t.text); err != nil {
+		return "", p.errorf("%v", err)
+	}
+	return t.text, nil
+}
+
+// value reads a label value. An empty value is only accepted after = and
+// !=, where it is followed by a comma or the end of the selector.
+func (p *parser) value(allowEmpty bool) (string, error) {
+	t := p.peek()
+	if allowEmpty && (t.kind == tokenEnd || t.kind == tokenComma) {
+		return "", nil
+	}
+	p.next()
+	if t.kind != tokenIdentifier {
+		return "", p.errorf("expected a label value but found %q", t.text)
+	}
+	if err := ValidateValue(t.text); err != nil {
+		return "", p.errorf("%v", err)
+	}
+	return t.text, nil
+}
+
+// values reads a parenthesized, comma separated list of at least one value.
+func (p *parser) values() ([]string, error) {
+	if t := p.next(); t.kind != tokenOpen {
+		return nil, p.errorf("expected '(' but found %q", t.text)
+	}
+
+	var values []string
+	for {
+		value, err := p.value(false)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch t := p.next(); t.kind {
+		case tokenClose:
+			return values, nil
+		case tokenComma:
+		default:
+			return nil, p.errorf("expected ',' or ')' but found %q", t.text)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package labels
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	selector, err := ParseSelector("tier=critical,language!=java, domain in (payments, billing),owner,!legacy,env notin (dev),x==")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Selector{
+		{Key: "tier", Operator: OpEquals, Values: []string{"critical"}},
+		{Key: "language", Operator: OpNotEquals, Values: []string{"java"}},
+		{Key: "domain", Operator: OpIn, Values: []string{"payments", "billing"}},
+		{Key: "owner", Operator: OpExists},
+		{Key: "legacy", Operator: OpDoesNotExist},
+		{Key: "env", Operator: OpNotIn, Values: []string{"dev"}},
+		{Key: "x", Operator: OpEquals, Values: []string{""}},
+	}, selector)
+	assert.Equal(t, "tier=critical,language!=java,domain in (payments,billing),owner,!legacy,env notin (dev),x=", selector.String())
+}
+
+func TestParseSelectorEmpty(t *testing.T) {
+	selector, err := ParseSelector("  ")
+
+	assert.NoError(t, err)
+	assert.Empty(t, selector)
+}
+
+func TestParseSelectorInvalid(t *testing.T) {
+	for _, s := range []string{
+		"tier=critical,",
+		",tier",
+		"tier critical",
+		"domain in payments",
+		"domain in ()",
+		"domain in (payments",
+		"domain in (payments billing)",
+		"tier=crit ical",
+		"-tier=critical",
+		"tier=(critical)",
+		"!tier=critical",
+		"!",
+	} {
+		_, err := ParseSelector(s)
+		assert.True(t, errors.Is(err, ErrInvalidSelector), s)
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"serviceCatalog/internal/constants"
+)
+
+// Admin marks requests that carry the configured admin token in the
+// X-Admin-Token header. Handlers check the flag with IsAdmin. Requests are
+// never rejected here, and no request is treated as admin when token is empty.
+func Admin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(constants.AdminTokenHeader)
+		if token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+			c.Set(constants.IsAdmin, true)
+		}
+		c.Next()
+	}
+}
+
+// IsAdmin reports whether the request was authenticated by the Admin middleware.
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(constants.IsAdmin)
+}
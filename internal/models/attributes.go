@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Attributes holds the free-form attributes of a service, stored as a
+// JSONB object. Their shape is constrained by the AttributeSchema.
+type Attributes map[string]interface{}
+
+// Value stores the attributes as JSON; nil attributes are stored as {}.
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads attributes stored as JSON. NULL reads as empty attributes.
+func (a *Attributes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = Attributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Attributes", value)
+	}
+
+	attributes := Attributes{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+	*a = attributes
+	return nil
+}
+
+// AttributeSchemaID is the ID of the single row holding the catalog's
+// attribute schema.
+const AttributeSchemaID = 1
+
+// AttributeSchema is the admin-managed JSON Schema document that the
+// attributes of every service must satisfy.
+type AttributeSchema struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	Document  string    `json:"-" gorm:"type:jsonb;not null"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// DependencyKind describes how a service talks to a service it depends on.
+type DependencyKind string
+
+const (
+	DependencySyncHTTP   DependencyKind = "sync-http"
+	DependencyAsyncQueue DependencyKind = "async-queue"
+	DependencyDatabase   DependencyKind = "database"
+)
+
+// DependencyKinds lists the accepted dependency kinds.
+var DependencyKinds = []DependencyKind{DependencySyncHTTP, DependencyAsyncQueue, DependencyDatabase}
+
+// Valid reports whether k is one of DependencyKinds.
+func (k DependencyKind) Valid() bool {
+	for _, kind := range DependencyKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Criticality rates how badly a service suffers when a dependency fails.
+type Criticality string
+
+const (
+	CriticalityLow    Criticality = "low"
+	CriticalityMedium Criticality = "medium"
+	CriticalityHigh   Criticality = "high"
+)
+
+// Criticalities lists the accepted criticalities, lowest first.
+var Criticalities = []Criticality{CriticalityLow, CriticalityMedium, CriticalityHigh}
+
+// Valid reports whether c is one of Criticalities.
+func (c Criticality) Valid() bool {
+	for _, criticality := range Criticalities {
+		if c == criticality {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceDependency is a directed edge from a service to a service it
+// depends on. Two services may be connected by edges of different kinds,
+// but not by two edges of the same kind.
+type ServiceDependency struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	ServiceID   uint           `json:"service_id" gorm:"not null;uniqueIndex:idx_service_dependencies_edge"`
+	DependsOnID uint           `json:"depends_on_id" gorm:"not null;index;uniqueIndex:idx_service_dependencies_edge"`
+	Kind        DependencyKind `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_service_dependencies_edge"`
+	Criticality Criticality    `json:"criticality,omitempty" gorm:"size:20"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// ServiceSummary identifies the service at the other end of a dependency.
+type ServiceSummary struct {
+	ID        uint           `json:"id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Lifecycle LifecycleStage `json:"lifecycle"`
+}
+
+// ToSummary converts the Service model to a ServiceSummary.
+func (s *Service) ToSummary() ServiceSummary {
+	return ServiceSummary{ID: s.ID, Name: s.Name, Slug: s.Slug, Lifecycle: s.Lifecycle}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// VersionDescriptorSet is the compiled protobuf FileDescriptorSet
+// describing the gRPC API of a version. A version has at most one;
+// uploading another replaces it.
+type VersionDescriptorSet struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	VersionID uint      `json:"version_id" gorm:"not null;uniqueIndex"`
+	Data      []byte    `json:"-" gorm:"type:bytea;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
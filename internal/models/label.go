@@ -0,0 +1,20 @@
+package models
+
+// ServiceLabel is a key/value label attached to a service, e.g.
+// tier=critical. Keys are unique per service.
+type ServiceLabel struct {
+	ID        uint   `json:"-" gorm:"primaryKey"`
+	ServiceID uint   `json:"-" gorm:"not null;uniqueIndex:idx_service_labels_key"`
+	Key       string `json:"key" gorm:"size:317;not null;uniqueIndex:idx_service_labels_key;index:idx_service_labels_key_value,priority:1"`
+	Value     string `json:"value" gorm:"size:63;not null;index:idx_service_labels_key_value,priority:2"`
+}
+
+// LabelMap converts labels to a key/value map. It never returns nil so
+// that services without labels serialize as an empty object.
+func LabelMap(labels []ServiceLabel) map[string]string {
+	m := make(map[string]string, len(labels))
+	for _, label := range labels {
+		m[label.Key] = label.Value
+	}
+	return m
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// LifecycleStage is the stage of a service in its lifecycle.
+type LifecycleStage string
+
+const (
+	LifecycleProposed     LifecycleStage = "proposed"
+	LifecycleExperimental LifecycleStage = "experimental"
+	LifecycleProduction   LifecycleStage = "production"
+	LifecycleDeprecated   LifecycleStage = "deprecated"
+	LifecycleRetired      LifecycleStage = "retired"
+)
+
+// LifecycleStages lists the lifecycle stages in order.
+var LifecycleStages = []LifecycleStage{
+	LifecycleProposed, LifecycleExperimental, LifecycleProduction, LifecycleDeprecated, LifecycleRetired,
+}
+
+// lifecycleTransitions maps every stage to the stages it may move to.
+// Retired is final; a deprecated service may return to production.
+var lifecycleTransitions = map[LifecycleStage][]LifecycleStage{
+	LifecycleProposed:     {LifecycleExperimental, LifecycleProduction, LifecycleRetired},
+	LifecycleExperimental: {LifecycleProduction, LifecycleDeprecated, LifecycleRetired},
+	LifecycleProduction:   {LifecycleDeprecated},
+	LifecycleDeprecated:   {LifecycleProduction, LifecycleRetired},
+	LifecycleRetired:      {},
+}
+
+// Valid reports whether s is one of LifecycleStages.
+func (s LifecycleStage) Valid() bool {
+	_, ok := lifecycleTransitions[s]
+	return ok
+}
+
+// Next returns the stages a service in stage s may move to.
+func (s LifecycleStage) Next() []LifecycleStage {
+	next := lifecycleTransitions[s]
+	if next == nil {
+		return []LifecycleStage{}
+	}
+	return next
+}
+
+// CanTransitionTo reports whether a service may move from s to stage.
+func (s LifecycleStage) CanTransitionTo(stage LifecycleStage) bool {
+	for _, next := range lifecycleTransitions[s] {
+		if next == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// LifecycleTransition records a change of a service's lifecycle stage,
+// who made it and why.
+type LifecycleTransition struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ServiceID uint           `json:"service_id" gorm:"not null;index"`
+	From      LifecycleStage `json:"from" gorm:"size:20;not null"`
+	To        LifecycleStage `json:"to" gorm:"size:20;not null"`
+	Actor     string         `json:"actor" gorm:"not null"`
+	Reason    string         `json:"reason"`
+	CreatedAt time.Time      `json:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// LinkType classifies a ServiceLink.
+type LinkType string
+
+const (
+	LinkRepository LinkType = "repository"
+	LinkRunbook    LinkType = "runbook"
+	LinkDashboard  LinkType = "dashboard"
+	LinkDocs       LinkType = "docs"
+)
+
+// LinkTypes lists the accepted link types in display order.
+var LinkTypes = []LinkType{LinkRepository, LinkRunbook, LinkDashboard, LinkDocs}
+
+// Valid reports whether t is one of LinkTypes.
+func (t LinkType) Valid() bool {
+	for _, linkType := range LinkTypes {
+		if t == linkType {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceLink is a typed URL attached to a service, such as its source
+// repository or on-call runbook. A service may have several links of the
+// same type, but not the same URL twice for one type.
+type ServiceLink struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ServiceID uint      `json:"-" gorm:"not null;uniqueIndex:idx_service_links_url"`
+	Type      LinkType  `json:"type" gorm:"size:20;not null;index;uniqueIndex:idx_service_links_url"`
+	URL       string    `json:"url" gorm:"size:2048;not null;uniqueIndex:idx_service_links_url"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
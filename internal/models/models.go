@@ -2,34 +2,79 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"serviceCatalog/internal/slug"
 	"time"
 )
 
 type Service struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	Name        string         `json:"name" gorm:"not null"`
+	Slug        string         `json:"slug" gorm:"size:100;uniqueIndex"`
 	Description string         `json:"description"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
-	Versions    []Version      `json:"versions,omitempty" gorm:"foreignKey:ServiceID"`
+	OwnerID     *uint          `json:"owner_id" gorm:"index"`
+
+	// Lifecycle defaults to production in the database so that services
+	// created before stages existed, which are already serving traffic,
+	// are backfilled as production; new services start as proposed.
+	Lifecycle LifecycleStage `json:"lifecycle" gorm:"size:20;not null;default:'production';index"`
+
+	Owner      *Team          `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
+	Versions   []Version      `json:"versions,omitempty" gorm:"foreignKey:ServiceID"`
+	Labels     []ServiceLabel `json:"labels,omitempty" gorm:"foreignKey:ServiceID"`
+	Links      []ServiceLink  `json:"links,omitempty" gorm:"foreignKey:ServiceID"`
+	Attributes Attributes     `json:"attributes" gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+// BeforeCreate generates the slug from the name when none was set.
+func (s *Service) BeforeCreate(tx *gorm.DB) error {
+	if s.Slug == "" {
+		s.Slug = slug.Make(s.Name)
+	}
+	return nil
 }
 
 // ServiceResponse is the API response structure
 type ServiceResponse struct {
-	ID          uint           `json:"id"`
-	Name        string         `json:"name"`
-	Description string         `json:"description"`
-	Versions    int            `json:"versions"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Slug        string            `json:"slug"`
+	Description string            `json:"description"`
+	Versions    int               `json:"versions"`
+	Owner       *TeamSummary      `json:"owner"`
+	Lifecycle   LifecycleStage    `json:"lifecycle"`
+	Labels      map[string]string `json:"labels"`
+	Attributes  Attributes        `json:"attributes"`
+	Links       []ServiceLink     `json:"links,omitempty"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
+
+	// LatestVersionHasSpec reports whether the latest published version
+	// has an OpenAPI specification. It is set by the handlers.
+	LatestVersionHasSpec bool `json:"latest_version_has_spec"`
 }
 
-// ToResponse converts the Service model to a ServiceResponse
+// ToResponse converts the Service model to a ServiceResponse.
+// Owner information, labels and links are included when the Owner, Labels
+// and Links associations are loaded.
 func (s *Service) ToResponse(versionCount int) ServiceResponse {
-	return ServiceResponse{
+	response := ServiceResponse{
 		ID:          s.ID,
 		Name:        s.Name,
+		Slug:        s.Slug,
 		Description: s.Description,
 		Versions:    versionCount,
+		Lifecycle:   s.Lifecycle,
+		Labels:      LabelMap(s.Labels),
+		Attributes:  s.Attributes,
+		Links:       s.Links,
+	}
+	if response.Attributes == nil {
+		response.Attributes = Attributes{}
+	}
+	if s.Owner != nil {
+		response.Owner = s.Owner.ToSummary()
 	}
+	return response
 }
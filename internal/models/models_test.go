@@ -10,6 +10,7 @@ func TestServiceToResponse(t *testing.T) {
 	service := Service{
 		ID:          1,
 		Name:        "Test Service",
+		Slug:        "test-service",
 		Description: "Test Description",
 	}
 
@@ -17,6 +18,70 @@ func TestServiceToResponse(t *testing.T) {
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.Equal(t, "Test Service", response.Name)
+	assert.Equal(t, "test-service", response.Slug)
 	assert.Equal(t, "Test Description", response.Description)
 	assert.Equal(t, 3, response.Versions)
+	assert.Nil(t, response.Owner)
+
+	service.Owner = &Team{ID: 2, Name: "Payments", ChatChannel: "#payments"}
+	response = service.ToResponse(3)
+
+	assert.Equal(t, &TeamSummary{ID: 2, Name: "Payments", ChatChannel: "#payments"}, response.Owner)
+}
+
+func TestAttributesValueAndScan(t *testing.T) {
+	value, err := Attributes(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", value)
+
+	value, err = Attributes{"costCenter": "42"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"costCenter":"42"}`, value)
+
+	var attributes Attributes
+	assert.NoError(t, attributes.Scan([]byte(`{"costCenter": "42"}`)))
+	assert.Equal(t, Attributes{"costCenter": "42"}, attributes)
+
+	assert.NoError(t, attributes.Scan(nil))
+	assert.Equal(t, Attributes{}, attributes)
+
+	assert.Error(t, attributes.Scan(42))
+}
+
+func TestLifecycleTransitions(t *testing.T) {
+	assert.True(t, LifecycleProposed.CanTransitionTo(LifecycleExperimental))
+	assert.True(t, LifecycleDeprecated.CanTransitionTo(LifecycleProduction))
+	assert.False(t, LifecycleRetired.CanTransitionTo(LifecycleProduction))
+	assert.False(t, LifecycleProduction.CanTransitionTo(LifecycleProduction))
+	assert.False(t, LifecycleProduction.CanTransitionTo(LifecycleRetired))
+
+	assert.Equal(t, []LifecycleStage{}, LifecycleRetired.Next())
+	assert.True(t, LifecycleExperimental.Valid())
+	assert.False(t, LifecycleStage("live").Valid())
+}
+
+func TestVersionStatusTransitions(t *testing.T) {
+	assert.True(t, VersionDraft.CanTransitionTo(VersionReleased))
+	assert.True(t, VersionDeprecated.CanTransitionTo(VersionEndOfLife))
+	assert.False(t, VersionDraft.CanTransitionTo(VersionDeprecated))
+	assert.False(t, VersionYanked.CanTransitionTo(VersionReleased))
+
+	assert.Equal(t, []VersionStatus{}, VersionEndOfLife.Next())
+	assert.False(t, VersionStatus("stable").Valid())
+}
+
+func TestVersionBeforeCreate(t *testing.T) {
+	version := Version{Number: "2.1.0-rc.1"}
+
+	err := version.BeforeCreate(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, VersionReleased, version.Status)
+	assert.Equal(t, int64(2), version.Major)
+	assert.Equal(t, int64(1), version.Minor)
+	assert.Equal(t, int64(0), version.Patch)
+	assert.Equal(t, "rc.1", version.PreRelease)
+
+	invalid := Version{Number: "2.1"}
+	assert.Error(t, invalid.BeforeCreate(nil))
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// VersionRequirement records that a version needs a version of another
+// service within Range, a semantic version range such as ">=2.0.0 <3.0.0".
+// A version requires each service at most once.
+type VersionRequirement struct {
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	VersionID   uint      `json:"-" gorm:"not null;uniqueIndex:idx_version_requirements_service"`
+	DependsOnID uint      `json:"service_id" gorm:"not null;index;uniqueIndex:idx_version_requirements_service"`
+	Range       string    `json:"range" gorm:"size:255;not null"`
+	CreatedAt   time.Time `json:"-"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// VersionSBOM records the software bill of materials of a version, whose
+// components are stored as SBOMComponents. A version has at most one;
+// uploading another replaces it with its components.
+type VersionSBOM struct {
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	VersionID   uint      `json:"version_id" gorm:"not null;uniqueIndex"`
+	Format      string    `json:"format" gorm:"size:20;not null"`
+	SpecVersion string    `json:"spec_version" gorm:"size:20;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SBOMComponent is a package listed in the SBOM of a version. PURL is the
+// canonical package URL; its type, namespace and name are also stored
+// apart so every version of a package is found through one index.
+type SBOMComponent struct {
+	ID            uint   `json:"-" gorm:"primaryKey"`
+	VersionID     uint   `json:"-" gorm:"not null;index"`
+	PURL          string `json:"purl,omitempty" gorm:"column:purl;type:text"`
+	PURLType      string `json:"-" gorm:"column:purl_type;size:255;index:idx_sbom_components_package,priority:1"`
+	PURLNamespace string `json:"-" gorm:"column:purl_namespace;type:text;index:idx_sbom_components_package,priority:2"`
+	PURLName      string `json:"-" gorm:"column:purl_name;type:text;index:idx_sbom_components_package,priority:3"`
+	Name          string `json:"name" gorm:"type:text;not null"`
+	Version       string `json:"version" gorm:"type:text"`
+	License       string `json:"license,omitempty" gorm:"type:text"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// VersionSpec is the OpenAPI document describing the API of a version. A
+// version has at most one; uploading another replaces it. Document holds
+// the bytes as uploaded, in Format ("yaml" or "json").
+type VersionSpec struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	VersionID uint      `json:"version_id" gorm:"not null;uniqueIndex"`
+	OpenAPI   string    `json:"openapi" gorm:"column:openapi;size:20;not null"`
+	Title     string    `json:"title"`
+	Format    string    `json:"format" gorm:"size:10;not null"`
+	Document  string    `json:"-" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Team is a group of people owning services.
+type Team struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	Name         string         `json:"name" gorm:"not null;uniqueIndex"`
+	ContactEmail string         `json:"contact_email"`
+	ChatChannel  string         `json:"chat_channel"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TeamSummary is the owner information embedded in a ServiceResponse
+type TeamSummary struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	ContactEmail string `json:"contact_email"`
+	ChatChannel  string `json:"chat_channel"`
+}
+
+// ToSummary converts the Team model to a TeamSummary
+func (t *Team) ToSummary() *TeamSummary {
+	return &TeamSummary{
+		ID:           t.ID,
+		Name:         t.Name,
+		ContactEmail: t.ContactEmail,
+		ChatChannel:  t.ChatChannel,
+	}
+}
@@ -1,12 +1,61 @@
 package models
 
 import (
+	"gorm.io/gorm"
+	"serviceCatalog/internal/semver"
 	"time"
 )
 
 type Version struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	ServiceID uint      `json:"service_id"`
-	Number    string    `json:"number" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ServiceID uint           `json:"service_id" gorm:"uniqueIndex:idx_versions_service_number"`
+	Number    string         `json:"number" gorm:"not null;uniqueIndex:idx_versions_service_number"`
+	Status    VersionStatus  `json:"status" gorm:"size:20;not null;default:'released';index"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// ReleaseNotes describes the changes of the version in Markdown.
+	ReleaseNotes string `json:"release_notes,omitempty" gorm:"type:text"`
+
+	// DeprecationDate is when the version was or will be deprecated and
+	// EndOfLifeDate when it stops being supported. Both are optional.
+	DeprecationDate *time.Time `json:"deprecation_date,omitempty"`
+	EndOfLifeDate   *time.Time `json:"end_of_life_date,omitempty"`
+
+	// Requires lists the ranges of versions of other services this version
+	// needs. It is only used to create them with the version; responses
+	// list them with the service slug instead.
+	Requires []VersionRequirement `json:"-" gorm:"foreignKey:VersionID"`
+
+	// Semantic version components parsed from Number, used for ordering
+	// and range queries in SQL. See semver.Version.PreReleaseKey.
+	Major         int64  `json:"-" gorm:"not null;default:0"`
+	Minor         int64  `json:"-" gorm:"not null;default:0"`
+	Patch         int64  `json:"-" gorm:"not null;default:0"`
+	PreRelease    string `json:"-" gorm:"not null;default:''"`
+	PreReleaseKey string `json:"-" gorm:"not null;default:'~'"`
+}
+
+// BeforeCreate fills the semantic version components from Number and
+// defaults the status to released. Version numbers are immutable, so they
+// are only parsed on insert.
+func (v *Version) BeforeCreate(tx *gorm.DB) error {
+	parsed, err := semver.Parse(v.Number)
+	if err != nil {
+		return err
+	}
+	v.SetComponents(parsed)
+	if v.Status == "" {
+		v.Status = VersionReleased
+	}
+	return nil
+}
+
+// SetComponents copies the parsed components of a version number onto v.
+func (v *Version) SetComponents(parsed semver.Version) {
+	v.Major = int64(parsed.Major)
+	v.Minor = int64(parsed.Minor)
+	v.Patch = int64(parsed.Patch)
+	v.PreRelease = parsed.PreRelease
+	v.PreReleaseKey = parsed.PreReleaseKey()
 }
@@ -0,0 +1,53 @@
+package models
+
+// VersionStatus is the release status of a version.
+type VersionStatus string
+
+const (
+	VersionDraft      VersionStatus = "draft"
+	VersionReleased   VersionStatus = "released"
+	VersionDeprecated VersionStatus = "deprecated"
+	VersionEndOfLife  VersionStatus = "end-of-life"
+	VersionYanked     VersionStatus = "yanked"
+)
+
+// VersionStatuses lists the version statuses in order.
+var VersionStatuses = []VersionStatus{
+	VersionDraft, VersionReleased, VersionDeprecated, VersionEndOfLife, VersionYanked,
+}
+
+// versionTransitions maps every status to the statuses it may move to.
+// End-of-life and yanked are final; a deprecated version may be released
+// again.
+var versionTransitions = map[VersionStatus][]VersionStatus{
+	VersionDraft:      {VersionReleased},
+	VersionReleased:   {VersionDeprecated, VersionYanked},
+	VersionDeprecated: {VersionReleased, VersionEndOfLife, VersionYanked},
+	VersionEndOfLife:  {},
+	VersionYanked:     {},
+}
+
+// Valid reports whether s is one of VersionStatuses.
+func (s VersionStatus) Valid() bool {
+	_, ok := versionTransitions[s]
+	return ok
+}
+
+// Next returns the statuses a version in status s may move to.
+func (s VersionStatus) Next() []VersionStatus {
+	next := versionTransitions[s]
+	if next == nil {
+		return []VersionStatus{}
+	}
+	return next
+}
+
+// CanTransitionTo reports whether a version may move from s to status.
+func (s VersionStatus) CanTransitionTo(status VersionStatus) bool {
+	for _, next := range versionTransitions[s] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,494 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeKind identifies what changed between two documents.
+type ChangeKind string
+
+const (
+	PathAdded           ChangeKind = "path-added"
+	PathRemoved         ChangeKind = "path-removed"
+	OperationAdded      ChangeKind = "operation-added"
+	OperationRemoved    ChangeKind = "operation-removed"
+	ParameterAdded      ChangeKind = "parameter-added"
+	ParameterRemoved    ChangeKind = "parameter-removed"
+	ParameterRequired   ChangeKind = "parameter-required"
+	ParameterOptional   ChangeKind = "parameter-optional"
+	RequestBodyAdded    ChangeKind = "request-body-added"
+	RequestBodyRemoved  ChangeKind = "request-body-removed"
+	RequestBodyRequired ChangeKind = "request-body-required"
+	ResponseAdded       ChangeKind = "response-added"
+	ResponseRemoved     ChangeKind = "response-removed"
+	MediaTypeAdded      ChangeKind = "media-type-added"
+	MediaTypeRemoved    ChangeKind = "media-type-removed"
+	TypeChanged         ChangeKind = "type-changed"
+	EnumValueAdded      ChangeKind = "enum-value-added"
+	EnumValueRemoved    ChangeKind = "enum-value-removed"
+	PropertyAdded       ChangeKind = "property-added"
+	PropertyRemoved     ChangeKind = "property-removed"
+	PropertyRequired    ChangeKind = "property-required"
+	PropertyOptional    ChangeKind = "property-optional"
+)
+
+// Change is a difference between two versions of an API. Path is the API
+// path, e.g. "/pets/{petId}", and Method the upper-case HTTP method, empty
+// for changes to a whole path. Location is the dotted path of the change
+// inside the operation, e.g. "responses.200.content.application/json.schema.properties.name".
+// Breaking changes can make existing consumers fail.
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Path     string     `json:"path"`
+	Method   string     `json:"method,omitempty"`
+	Location string     `json:"location,omitempty"`
+	Message  string     `json:"message"`
+	Breaking bool       `json:"breaking"`
+}
+
+// HasBreaking reports whether any of changes is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// direction tells whether a schema describes data sent by consumers or
+// returned to them, which decides whether widening or narrowing it breaks
+// them.
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+// templateParam matches the parameters of path templates, so paths that
+// only rename a parameter, like /pets/{id} and /pets/{petId}, match.
+var templateParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// ErrTooComplex is returned when a comparison gives up after the step
+// limit.
+var ErrTooComplex = errors.New("too many schemas to compare")
+
+// Compare lists the changes from one version of an API to the next, in
+// the order of the from document followed by additions. Paths, operations,
+// parameters, request bodies, responses and their media types are
+// compared, and schemas for their type, enum values, required properties,
+// properties and array items. Composed schemas (allOf, oneOf, anyOf) are
+// not compared. A pair of schemas is compared once per direction, so the
+// changes of a schema used in several places are only reported where it
+// is reached first. Comparison gives up with ErrTooComplex after comparing
+// maxSteps schemas.
+func Compare(from, to *Document, maxSteps int) ([]Change, error) {
+	c := comparer{from: from, to: to, maxSteps: maxSteps, compared: map[schemaPair]bool{}}
+	c.paths()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.changes, nil
+}
+
+type comparer struct {
+	from, to *Document
+	changes  []Change
+	maxSteps int
+	steps    int
+	err      error
+	// compared holds the schema pairs already compared, so shared and
+	// recursive schemas are walked once
+	compared map[schemaPair]bool
+}
+
+// schemaPair identifies a comparison of two schemas in a direction.
+type schemaPair struct {
+	from, to *yaml.Node
+	dir      direction
+}
+
+// operation identifies where a change is reported.
+type operation struct {
+	path, method string
+}
+
+func (c *comparer) add(op operation, kind ChangeKind, location string, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Kind:     kind,
+		Path:     op.path,
+		Method:   op.method,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+type pathItem struct {
+	path string
+	node *yaml.Node
+}
+
+// pathItems returns the path items of doc keyed by their template with
+// parameter names removed, and the keys in document order.
+func pathItems(doc *Document) ([]string, map[string]pathItem) {
+	var keys []string
+	items := map[string]pathItem{}
+	pairs(field(doc.root, "paths"), func(path string, node *yaml.Node) {
+		if !strings.HasPrefix(path, "/") {
+			return
+		}
+		key := templateParam.ReplaceAllString(path, "{}")
+		if _, ok := items[key]; !ok {
+			keys = append(keys, key)
+			items[key] = pathItem{path: path, node: doc.deref(node)}
+		}
+	})
+	return keys, items
+}
+
+func (c *comparer) paths() {
+	fromKeys, fromItems := pathItems(c.from)
+	toKeys, toItems := pathItems(c.to)
+
+	for _, key := range fromKeys {
+		fromItem := fromItems[key]
+		toItem, ok := toItems[key]
+		if !ok {
+			c.add(operation{path: fromItem.path}, PathRemoved, "", true, "path %s was removed", fromItem.path)
+			continue
+		}
+		c.pathItem(fromItem, toItem)
+	}
+	for _, key := range toKeys {
+		if _, ok := fromItems[key]; !ok {
+			c.add(operation{path: toItems[key].path}, PathAdded, "", false, "path %s was added", toItems[key].path)
+		}
+	}
+}
+
+func (c *comparer) pathItem(fromItem, toItem pathItem) {
+	path := toItem.path
+	for _, method := range Methods {
+		op := operation{path: path, method: strings.ToUpper(method)}
+		fromOperation := field(fromItem.node, method)
+		toOperation := field(toItem.node, method)
+		switch {
+		case fromOperation == nil && toOperation == nil:
+		case toOperation == nil:
+			c.add(op, OperationRemoved, "", true, "operation %s %s was removed", op.method, path)
+		case fromOperation == nil:
+			c.add(op, OperationAdded, "", false, "operation %s %s was added", op.method, path)
+		default:
+			fromParameters := c.from.parameters(fromItem.path, fromItem.node, fromOperation)
+			toParameters := c.to.parameters(toItem.path, toItem.node, toOperation)
+			c.parameters(op, fromParameters, toParameters)
+			c.requestBody(op, c.from.deref(field(fromOperation, "requestBody")), c.to.deref(field(toOperation, "requestBody")))
+			c.responses(op, field(fromOperation, "responses"), field(toOperation, "responses"))
+		}
+	}
+}
+
+type parameter struct {
+	key, in, name string
+	required      bool
+	schema        *yaml.Node
+}
+
+// parameters returns the parameters of an operation in order, including
+// those inherited from its path item unless the operation overrides them.
+// Header names are case-insensitive, so their keys are lower case, and
+// path parameters are keyed by their position in the path template.
+func (d *Document) parameters(path string, item, op *yaml.Node) []parameter {
+	positions := map[string]int{}
+	for i, param := range templateParam.FindAllString(path, -1) {
+		positions[strings.Trim(param, "{}")] = i
+	}
+
+	var result []parameter
+	index := map[string]int{}
+	for _, list := range []*yaml.Node{field(item, "parameters"), field(op, "parameters")} {
+		list = resolve(list)
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, node := range list.Content {
+			node = d.deref(node)
+			name, _ := stringValue(field(node, "name"))
+			in, _ := stringValue(field(node, "in"))
+			key := in + " " + name
+			if position, ok := positions[name]; ok && in == "path" {
+				key = fmt.Sprintf("path {%d}", position)
+			} else if in == "header" {
+				key = in + " " + strings.ToLower(name)
+			}
+			param := parameter{key: key, in: in, name: name, required: isTrue(field(node, "required")), schema: field(node, "schema")}
+			if i, ok := index[key]; ok {
+				result[i] = param
+				continue
+			}
+			index[key] = len(result)
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+func (c *comparer) parameters(op operation, from, to []parameter) {
+	toByKey := map[string]parameter{}
+	for _, param := range to {
+		toByKey[param.key] = param
+	}
+	fromKeys := map[string]bool{}
+
+	for _, fromParam := range from {
+		fromKeys[fromParam.key] = true
+		location := "parameters." + fromParam.in + "." + fromParam.name
+		toParam, ok := toByKey[fromParam.key]
+		if !ok {
+			c.add(op, ParameterRemoved, location, false, "%s parameter %s was removed", fromParam.in, fromParam.name)
+			continue
+		}
+		if !fromParam.required && toParam.required {
+			c.add(op, ParameterRequired, location, true, "%s parameter %s became required", fromParam.in, fromParam.name)
+		} else if fromParam.required && !toParam.required {
+			c.add(op, ParameterOptional, location, false, "%s parameter %s became optional", fromParam.in, fromParam.name)
+		}
+		c.schema(op, location+".schema", request, fromParam.schema, toParam.schema)
+	}
+
+	for _, toParam := range to {
+		if fromKeys[toParam.key] {
+			continue
+		}
+		location := "parameters." + toParam.in + "." + toParam.name
+		if toParam.required {
+			c.add(op, ParameterAdded, location, true, "required %s parameter %s was added", toParam.in, toParam.name)
+		} else {
+			c.add(op, ParameterAdded, location, false, "optional %s parameter %s was added", toParam.in, toParam.name)
+		}
+	}
+}
+
+func (c *comparer) requestBody(op operation, from, to *yaml.Node) {
+	required := isTrue(field(to, "required"))
+	switch {
+	case from == nil && to == nil:
+	case from == nil:
+		c.add(op, RequestBodyAdded, "requestBody", required, "request body was added")
+	case to == nil:
+		c.add(op, RequestBodyRemoved, "requestBody", false, "request body was removed")
+	default:
+		if required && !isTrue(field(from, "required")) {
+			c.add(op, RequestBodyRequired, "requestBody", true, "request body became required")
+		}
+		c.content(op, "requestBody.content", request, field(from, "content"), field(to, "content"))
+	}
+}
+
+func (c *comparer) responses(op operation, from, to *yaml.Node) {
+	pairs(from, func(code string, fromResponse *yaml.Node) {
+		location := "responses." + code
+		toResponse := field(to, code)
+		if toResponse == nil {
+			c.add(op, ResponseRemoved, location, true, "response %s was removed", code)
+			return
+		}
+		fromResponse = c.from.deref(fromResponse)
+		toResponse = c.to.deref(toResponse)
+		c.content(op, location+".content", response, field(fromResponse, "content"), field(toResponse, "content"))
+	})
+	pairs(to, func(code string, _ *yaml.Node) {
+		if field(from, code) == nil {
+			c.add(op, ResponseAdded, "responses."+code, false, "response %s was added", code)
+		}
+	})
+}
+
+// content compares the media types of a request body or response. A media
+// type that is no longer accepted or returned breaks consumers using it.
+func (c *comparer) content(op operation, location string, dir direction, from, to *yaml.Node) {
+	pairs(from, func(mediaType string, fromMedia *yaml.Node) {
+		toMedia := field(to, mediaType)
+		if toMedia == nil {
+			c.add(op, MediaTypeRemoved, child(location, mediaType), true, "media type %s was removed", mediaType)
+			return
+		}
+		c.schema(op, child(location, mediaType)+".schema", dir, field(fromMedia, "schema"), field(toMedia, "schema"))
+	})
+	pairs(to, func(mediaType string, _ *yaml.Node) {
+		if field(from, mediaType) == nil {
+			c.add(op, MediaTypeAdded, child(location, mediaType), false, "media type %s was added", mediaType)
+		}
+	})
+}
+
+// schema compares two schemas. Requests break when they accept less than
+// before: a changed type, removed enum values or new required properties.
+// Responses break when they return something consumers did not expect: a
+// changed type, new enum values or required properties that may be
+// missing.
+func (c *comparer) schema(op operation, location string, dir direction, from, to *yaml.Node) {
+	if c.err != nil {
+		return
+	}
+	c.steps++
+	if c.steps > c.maxSteps {
+		c.err = ErrTooComplex
+		return
+	}
+
+	from = c.from.deref(from)
+	to = c.to.deref(to)
+	if from == nil || to == nil {
+		return
+	}
+	pair := schemaPair{from: from, to: to, dir: dir}
+	if c.compared[pair] {
+		return
+	}
+	c.compared[pair] = true
+
+	if fromType, toType := schemaType(from), schemaType(to); fromType != "" && toType != "" && fromType != toType {
+		c.add(op, TypeChanged, location, true, "type changed from %s to %s", fromType, toType)
+		return
+	}
+
+	if fromEnum, toEnum := enumValues(from), enumValues(to); fromEnum != nil && toEnum != nil {
+		for _, value := range difference(fromEnum, toEnum) {
+			c.add(op, EnumValueRemoved, location, dir == request, "enum value %q was removed", value)
+		}
+		for _, value := range difference(toEnum, fromEnum) {
+			c.add(op, EnumValueAdded, location, dir == response, "enum value %q was added", value)
+		}
+	}
+
+	fromRequired := requiredSet(from)
+	toRequired := requiredSet(to)
+	fromProperties := field(from, "properties")
+	toProperties := field(to, "properties")
+
+	pairs(fromProperties, func(name string, fromProperty *yaml.Node) {
+		propertyLocation := location + ".properties." + name
+		toProperty := field(toProperties, name)
+		if toProperty == nil {
+			if fromRequired[name] {
+				c.add(op, PropertyRemoved, propertyLocation, dir == response, "required property %s was removed", name)
+			} else {
+				c.add(op, PropertyRemoved, propertyLocation, false, "property %s was removed", name)
+			}
+			return
+		}
+		if !fromRequired[name] && toRequired[name] {
+			c.add(op, PropertyRequired, propertyLocation, dir == request, "property %s became required", name)
+		} else if fromRequired[name] && !toRequired[name] {
+			c.add(op, PropertyOptional, propertyLocation, dir == response, "property %s is no longer required", name)
+		}
+		c.schema(op, propertyLocation, dir, fromProperty, toProperty)
+	})
+	pairs(toProperties, func(name string, _ *yaml.Node) {
+		if field(fromProperties, name) != nil {
+			return
+		}
+		propertyLocation := location + ".properties." + name
+		if toRequired[name] {
+			c.add(op, PropertyAdded, propertyLocation, dir == request, "required property %s was added", name)
+		} else {
+			c.add(op, PropertyAdded, propertyLocation, false, "property %s was added", name)
+		}
+	})
+
+	c.schema(op, location+".items", dir, field(from, "items"), field(to, "items"))
+}
+
+// deref follows local references, such as "#/components/schemas/Pet",
+// until it reaches a value without one. External and dangling references
+// are returned as they are.
+func (d *Document) deref(node *yaml.Node) *yaml.Node {
+	node = resolve(node)
+	// Bounded, so reference cycles terminate
+	for i := 0; i < 32; i++ {
+		ref, ok := stringValue(field(node, "$ref"))
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return node
+		}
+		target := d.pointer(ref)
+		if target == nil {
+			return node
+		}
+		node = target
+	}
+	return node
+}
+
+// schemaType returns the type of a schema, with the types of 3.1 type
+// arrays sorted and joined by commas, or "" when it has none.
+func schemaType(schema *yaml.Node) string {
+	node := field(schema, "type")
+	if value, ok := stringValue(node); ok {
+		return value
+	}
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return ""
+	}
+	var types []string
+	for _, item := range node.Content {
+		if value, ok := stringValue(item); ok {
+			types = append(types, value)
+		}
+	}
+	sort.Strings(types)
+	return strings.Join(types, ",")
+}
+
+// enumValues returns the scalar enum values of a schema in order, or nil
+// when it has no enum.
+func enumValues(schema *yaml.Node) []string {
+	node := field(schema, "enum")
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	values := []string{}
+	for _, item := range node.Content {
+		if item = resolve(item); item.Kind == yaml.ScalarNode {
+			values = append(values, item.Value)
+		}
+	}
+	return values
+}
+
+func requiredSet(schema *yaml.Node) map[string]bool {
+	required := map[string]bool{}
+	node := field(schema, "required")
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return required
+	}
+	for _, item := range node.Content {
+		if name, ok := stringValue(item); ok {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+// difference returns the values of a missing from b, in the order of a.
+func difference(a, b []string) []string {
+	present := map[string]bool{}
+	for _, value := range b {
+		present[value] = true
+	}
+	var missing []string
+	for _, value := range a {
+		if !present[value] {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
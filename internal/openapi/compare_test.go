@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petsV1 = `openapi: 3.0.3
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: X-Trace, in: header, schema: {type: string}}
+      responses:
+        "200":
+          description: Pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Pet"}
+            application/xml:
+              schema: {type: string}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Pet"}
+      responses:
+        "201": {description: Created}
+  /pets/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: A pet}
+        "404": {description: Not found}
+  /stores:
+    get:
+      responses:
+        "200": {description: Stores}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      properties:
+        name: {type: string}
+        kind: {type: string, enum: [cat, dog]}
+        age: {type: integer}
+        parent: {$ref: "#/components/schemas/Pet"}
+`
+
+const petsV2 = `openapi: 3.0.3
+info: {title: Pets, version: "2.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: string}}
+        - {name: x-trace, in: header, schema: {type: string}}
+        - {name: offset, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: Pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Pet"}
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Pet"}
+      responses:
+        "201": {description: Created}
+  /pets/{petId}:
+    get:
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: A pet}
+        "410": {description: Gone}
+    delete:
+      responses:
+        "204": {description: Deleted}
+  /owners:
+    get:
+      responses:
+        "200": {description: Owners}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [fullName, kind, age]
+      properties:
+        fullName: {type: string}
+        kind: {type: string, enum: [cat, bird]}
+        age: {type: integer}
+        parent: {$ref: "#/components/schemas/Pet"}
+`
+
+func TestCompare(t *testing.T) {
+	from, err := Parse([]byte(petsV1))
+	require.NoError(t, err)
+	to, err := Parse([]byte(petsV2))
+	require.NoError(t, err)
+
+	changes, err := Compare(from, to, 1000)
+	require.NoError(t, err)
+
+	type summary struct {
+		Kind     ChangeKind
+		Method   string
+		Location string
+		Breaking bool
+	}
+	var got []summary
+	for _, change := range changes {
+		got = append(got, summary{change.Kind, change.Method, change.Location, change.Breaking})
+	}
+
+	const body = "requestBody.content.application/json.schema"
+	const pets = "responses.200.content.application/json.schema.items"
+	assert.Equal(t, []summary{
+		{ParameterRequired, "GET", "parameters.query.limit", true},
+		{TypeChanged, "GET", "parameters.query.limit.schema", true},
+		{ParameterAdded, "GET", "parameters.query.offset", false},
+		// Response properties: removing a required one and a new enum
+		// value break consumers, newly required ones do not
+		{PropertyRemoved, "GET", pets + ".properties.name", true},
+		{EnumValueRemoved, "GET", pets + ".properties.kind", false},
+		{EnumValueAdded, "GET", pets + ".properties.kind", true},
+		{PropertyRequired, "GET", pets + ".properties.age", false},
+		{PropertyAdded, "GET", pets + ".properties.fullName", false},
+		{MediaTypeRemoved, "GET", "responses.200.content.application/xml", true},
+		{RequestBodyRequired, "POST", "requestBody", true},
+		// Request properties: the opposite
+		{PropertyRemoved, "POST", body + ".properties.name", false},
+		{EnumValueRemoved, "POST", body + ".properties.kind", true},
+		{EnumValueAdded, "POST", body + ".properties.kind", false},
+		{PropertyRequired, "POST", body + ".properties.age", true},
+		{PropertyAdded, "POST", body + ".properties.fullName", true},
+		// Renaming a path parameter changes nothing
+		{ResponseRemoved, "GET", "responses.404", true},
+		{ResponseAdded, "GET", "responses.410", false},
+		{OperationAdded, "DELETE", "", false},
+		{PathRemoved, "", "", true},
+		{PathAdded, "", "", false},
+	}, got)
+
+	assert.Equal(t, "/pets/{petId}", changes[15].Path)
+	assert.Equal(t, "required property name was removed", changes[3].Message)
+	assert.Equal(t, "path /stores was removed", changes[18].Message)
+	assert.True(t, HasBreaking(changes))
+}
+
+func TestCompareIdentical(t *testing.T) {
+	doc, err := Parse([]byte(petsV1))
+	require.NoError(t, err)
+
+	changes, err := Compare(doc, doc, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.False(t, HasBreaking(changes))
+}
+
+// deepSpec returns a document whose response schema nests depth schemas,
+// each referencing the next through two properties, ending in leafType.
+func deepSpec(depth int, leafType string) string {
+	var b strings.Builder
+	b.WriteString(`openapi: 3.0.3
+info: {title: Deep, version: "1.0"}
+paths:
+  /deep:
+    get:
+      responses:
+        "200":
+          description: Deep
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/S0"}
+components:
+  schemas:
+`)
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&b, "    S%d:\n      type: object\n      properties:\n", i)
+		fmt.Fprintf(&b, "        a: {$ref: \"#/components/schemas/S%d\"}\n", i+1)
+		fmt.Fprintf(&b, "        b: {$ref: \"#/components/schemas/S%d\"}\n", i+1)
+	}
+	fmt.Fprintf(&b, "    S%d: {type: %s}\n", depth, leafType)
+	return b.String()
+}
+
+func TestCompareSharedSchemas(t *testing.T) {
+	from, err := Parse([]byte(deepSpec(40, "string")))
+	require.NoError(t, err)
+	to, err := Parse([]byte(deepSpec(40, "integer")))
+	require.NoError(t, err)
+
+	// Every schema pair is compared once, so the change is reported at
+	// the first of its 2^40 locations
+	changes, err := Compare(from, to, 1000)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, TypeChanged, changes[0].Kind)
+	assert.Equal(t, "responses.200.content.application/json.schema"+strings.Repeat(".properties.a", 40), changes[0].Location)
+
+	_, err = Compare(from, to, 50)
+	assert.ErrorIs(t, err, ErrTooComplex)
+}
@@ -0,0 +1,238 @@
+// Package openapi reads OpenAPI 3.x documents written in YAML or JSON,
+// checks their structure and converts them between the two formats.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization of a document.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ErrInvalidDocument is returned for documents that cannot be read at all,
+// as opposed to readable documents that fail Validate.
+var ErrInvalidDocument = errors.New("invalid OpenAPI document")
+
+// versionPattern matches the openapi field of 3.x documents and captures
+// the minor version.
+var versionPattern = regexp.MustCompile(`^3\.(\d+)\.\d+$`)
+
+// Document is a parsed OpenAPI document. It keeps the bytes it was parsed
+// from, so it is written back unchanged in its own format.
+type Document struct {
+	// Version is the value of the openapi field, e.g. "3.1.0", and empty
+	// when the field is missing or not a string.
+	Version string
+	// Format is the serialization the document was parsed from.
+	Format Format
+
+	raw  []byte
+	root *yaml.Node
+}
+
+// Parse reads a YAML or JSON document. Documents starting with "{" are
+// read as JSON, anything else as YAML. Duplicate keys, excessive aliasing
+// and documents that are not an object are refused with an error wrapping
+// ErrInvalidDocument; the content itself is checked by Validate.
+func Parse(data []byte) (*Document, error) {
+	format := FormatYAML
+	source := data
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// Compact JSON is valid YAML, whitespace such as tabs is not
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, trimmed); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		format = FormatJSON
+		source = compact.Bytes()
+	}
+
+	// Decoding into a value rejects duplicate keys and alias bombs, which
+	// decoding into a node does not
+	var value interface{}
+	if err := yaml.Unmarshal(source, &value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(source, &node); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	if len(node.Content) == 0 || resolve(node.Content[0]).Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: the document must be an object", ErrInvalidDocument)
+	}
+
+	doc := &Document{Format: format, raw: data, root: resolve(node.Content[0])}
+	doc.Version, _ = stringValue(field(doc.root, "openapi"))
+	return doc, nil
+}
+
+// Info returns the title and version from the info object of the
+// document, empty when they are missing.
+func (d *Document) Info() (title, version string) {
+	info := field(d.root, "info")
+	title, _ = stringValue(field(info, "title"))
+	version, _ = stringValue(field(info, "version"))
+	return title, version
+}
+
+// Marshal returns the document in the given format. The original bytes
+// are returned when the format is the one the document was parsed from;
+// otherwise the document is converted, keeping the order of its keys.
+func (d *Document) Marshal(format Format) ([]byte, error) {
+	if format == d.Format {
+		return d.raw, nil
+	}
+
+	switch format {
+	case FormatJSON:
+		var compact bytes.Buffer
+		if err := writeJSON(&compact, d.root); err != nil {
+			return nil, err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+			return nil, err
+		}
+		indented.WriteByte('\n')
+		return indented.Bytes(), nil
+	case FormatYAML:
+		var out bytes.Buffer
+		encoder := yaml.NewEncoder(&out)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(blockStyle(d.root)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// writeJSON writes node to buf as compact JSON. Mapping keys are always
+// written as strings, so a response code 200 becomes "200".
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	node = resolve(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeScalar(buf, resolve(node.Content[i]).Value); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		// Timestamps stay strings instead of being reformatted
+		if node.ShortTag() == "!!timestamp" {
+			return writeScalar(buf, node.Value)
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		return writeScalar(buf, value)
+	}
+	return nil
+}
+
+// writeScalar writes value as JSON without escaping HTML characters.
+func writeScalar(buf *bytes.Buffer, value interface{}) error {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Drop the newline added by Encode
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// blockStyle returns a copy of node with the flow and quoting styles of
+// JSON removed, so it is written as block YAML. The encoder still quotes
+// strings that would otherwise read as another type.
+func blockStyle(node *yaml.Node) *yaml.Node {
+	node = resolve(node)
+	copied := *node
+	copied.Style = 0
+	copied.Anchor = ""
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = blockStyle(child)
+	}
+	return &copied
+}
+
+// resolve follows aliases to the node they refer to.
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// field returns the value of key in a mapping node, or nil when node is
+// not a mapping or has no such key.
+func field(node *yaml.Node, key string) *yaml.Node {
+	node = resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if resolve(node.Content[i]).Value == key {
+			return resolve(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// stringValue returns the value of a string scalar.
+func stringValue(node *yaml.Node) (string, bool) {
+	node = resolve(node)
+	if node == nil || node.Kind != yaml.ScalarNode || node.ShortTag() != "!!str" {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// pairs calls fn for every key and value of a mapping node in order.
+func pairs(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	node = resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(resolve(node.Content[i]).Value, resolve(node.Content[i+1]))
+	}
+}
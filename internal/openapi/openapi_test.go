@@ -0,0 +1,205 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstore = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: "1.0"
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: A pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+  responses:
+    Error:
+      description: Unexpected error
+`
+
+func TestParse(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		doc, err := Parse([]byte(petstore))
+		require.NoError(t, err)
+		assert.Equal(t, FormatYAML, doc.Format)
+		assert.Equal(t, "3.0.3", doc.Version)
+		title, version := doc.Info()
+		assert.Equal(t, "Petstore", title)
+		assert.Equal(t, "1.0", version)
+		assert.Empty(t, doc.Validate())
+	})
+
+	t.Run("JSON with tabs", func(t *testing.T) {
+		doc, err := Parse([]byte("{\n\t\"openapi\": \"3.1.0\",\n\t\"info\": {\"title\": \"A\", \"version\": \"1\"},\n\t\"webhooks\": {}\n}"))
+		require.NoError(t, err)
+		assert.Equal(t, FormatJSON, doc.Format)
+		assert.Equal(t, "3.1.0", doc.Version)
+		assert.Empty(t, doc.Validate())
+	})
+
+	for name, data := range map[string]string{
+		"empty":          "",
+		"not an object":  "- openapi",
+		"malformed JSON": `{"openapi": "3.0.0",}`,
+		"duplicate keys": "openapi: 3.0.0\nopenapi: 3.1.0\n",
+		"malformed YAML": "openapi: [3.0.0\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.True(t, errors.Is(err, ErrInvalidDocument), "got %v", err)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	require.NoError(t, err)
+
+	same, err := doc.Marshal(FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, petstore, string(same))
+
+	data, err := doc.Marshal(FormatJSON)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	responses := decoded["paths"].(map[string]interface{})["/pets/{petId}"].(map[string]interface{})["get"].(map[string]interface{})["responses"]
+	assert.Contains(t, responses, "200")
+	assert.Regexp(t, `^\{\n  "openapi": "3.0.3",\n  "info"`, string(data))
+
+	// Converting back keeps the key order and quotes strings that would
+	// read as numbers
+	fromJSON, err := Parse(data)
+	require.NoError(t, err)
+	yamlData, err := fromJSON.Marshal(FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, petstore, string(yamlData))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     []Violation
+	}{
+		{
+			name:     "missing required fields",
+			document: "openapi: 2.0\ninfo: {}\n",
+			want: []Violation{
+				{Path: "openapi", Message: `is required and must be a string such as "3.0.3"`},
+				{Path: "info.title", Message: "is required"},
+				{Path: "info.version", Message: "is required"},
+				{Path: "", Message: "must define paths, components or webhooks"},
+			},
+		},
+		{
+			name:     "swagger version",
+			document: "openapi: \"2.0.0\"\ninfo: {title: A, version: \"1\"}\npaths: {}\n",
+			want:     []Violation{{Path: "openapi", Message: `must be an OpenAPI 3.x version such as "3.0.3"`}},
+		},
+		{
+			name:     "paths required in 3.0",
+			document: "openapi: 3.0.0\ninfo: {title: A, version: \"1\"}\ncomponents: {}\n",
+			want:     []Violation{{Path: "paths", Message: "is required"}},
+		},
+		{
+			name: "operations",
+			document: `openapi: 3.0.0
+info: {title: A, version: "1"}
+paths:
+  pets:
+    get: {}
+  /pets:
+    GET: {}
+    get:
+      operationId: list
+      responses:
+        "200": {}
+        "600": {description: x}
+  /pets/{id}:
+    get:
+      operationId: list
+      parameters:
+        - {name: id, in: path}
+        - {name: q, in: body}
+      responses:
+        default: {$ref: "#/components/responses/Missing"}
+    post:
+      requestBody: {}
+`,
+			want: []Violation{
+				{Path: "paths.pets", Message: `must start with "/"`},
+				{Path: "paths./pets.GET", Message: "is not a known path item field"},
+				{Path: "paths./pets.get.responses.200.description", Message: "is required"},
+				{Path: "paths./pets.get.responses.600", Message: `must be "default", an HTTP status code or a range such as "4XX"`},
+				{Path: "paths./pets/{id}.get.operationId", Message: "duplicates the operationId of paths./pets.get"},
+				{Path: "paths./pets/{id}.get.parameters.0.required", Message: "must be true for path parameters"},
+				{Path: "paths./pets/{id}.get.parameters.1.in", Message: "must be one of query, header, path, cookie"},
+				{Path: "paths./pets/{id}.post.requestBody.content", Message: "is required"},
+				{Path: "paths./pets/{id}.post.responses", Message: "is required"},
+				{Path: "paths./pets/{id}.get.responses.default.$ref", Message: `references "#/components/responses/Missing", which does not exist`},
+			},
+		},
+		{
+			name: "responses optional in 3.1",
+			document: `openapi: 3.1.0
+info: {title: A, version: "1"}
+servers: [{description: no url}]
+paths:
+  /pets: {get: {}}
+components:
+  schemas:
+    Pet/Dog: {}
+`,
+			want: []Violation{
+				{Path: "servers.0.url", Message: "is required"},
+				{Path: "components.schemas.Pet/Dog", Message: `must only contain letters, digits, ".", "-" and "_"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.document))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, doc.Validate())
+		})
+	}
+}
+
+func TestPointer(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	require.NoError(t, err)
+
+	assert.NotNil(t, doc.pointer("#/paths/~1pets~1%7BpetId%7D/parameters/0"))
+	assert.NotNil(t, doc.pointer("#"))
+	assert.Nil(t, doc.pointer("#/paths/~1pets~1{petId}/parameters/1"))
+	assert.Nil(t, doc.pointer("#components"))
+}
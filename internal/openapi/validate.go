@@ -0,0 +1,410 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Methods are the HTTP methods a path item can define operations for, in
+// the order they appear in the specification.
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// pathItemFields are the fixed fields of a path item besides operations.
+var pathItemFields = map[string]bool{
+	"$ref": true, "summary": true, "description": true, "servers": true, "parameters": true,
+}
+
+// parameterLocations are the allowed values of the in field of parameters.
+var parameterLocations = map[string]bool{"query": true, "header": true, "path": true, "cookie": true}
+
+// componentTypes are the fields of the components object, each mapping
+// names to reusable objects.
+var componentTypes = []string{
+	"schemas", "responses", "parameters", "examples", "requestBodies",
+	"headers", "securitySchemes", "links", "callbacks", "pathItems",
+}
+
+var (
+	statusCodePattern    = regexp.MustCompile(`^[1-5](\d\d|XX)$`)
+	componentNamePattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+)
+
+// Violation is a structural problem in a document. Path is the dotted path
+// of the offending value, e.g. "paths./pets.get.responses", and empty for
+// the document itself.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+// Validate checks the structure of the document against the OpenAPI 3.x
+// specification: required fields, the shape of paths, operations,
+// parameters, responses and components, unique operation IDs and local
+// references. Schemas are not validated. Violations are returned in
+// document order.
+func (d *Document) Validate() []Violation {
+	v := validator{doc: d, operationIDs: map[string]string{}}
+	v.validate()
+	return v.violations
+}
+
+type validator struct {
+	doc          *Document
+	violations   []Violation
+	operationIDs map[string]string
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate() {
+	root := v.doc.root
+
+	if version, ok := stringValue(field(root, "openapi")); !ok {
+		v.add("openapi", "is required and must be a string such as \"3.0.3\"")
+	} else if !versionPattern.MatchString(version) {
+		v.add("openapi", "must be an OpenAPI 3.x version such as \"3.0.3\"")
+	}
+	// Operations only have to declare responses before 3.1
+	responsesRequired := v.doc.minor() == 0
+
+	info := field(root, "info")
+	if v.object("info", info, true) {
+		v.requiredString("info.title", field(info, "title"))
+		v.requiredString("info.version", field(info, "version"))
+	}
+
+	v.servers("servers", field(root, "servers"))
+
+	paths := field(root, "paths")
+	switch {
+	case paths != nil:
+		v.paths(paths, responsesRequired)
+	case responsesRequired:
+		v.add("paths", "is required")
+	case field(root, "components") == nil && field(root, "webhooks") == nil:
+		v.add("", "must define paths, components or webhooks")
+	}
+
+	if webhooks := field(root, "webhooks"); v.object("webhooks", webhooks, false) {
+		pairs(webhooks, func(name string, item *yaml.Node) {
+			v.pathItem(child("webhooks", name), item, responsesRequired)
+		})
+	}
+
+	if components := field(root, "components"); v.object("components", components, false) {
+		v.components(components, responsesRequired)
+	}
+
+	if tags := field(root, "tags"); v.array("tags", tags) {
+		for i, tag := range tags.Content {
+			path := index("tags", i)
+			if v.object(path, tag, true) {
+				v.requiredString(child(path, "name"), field(tag, "name"))
+			}
+		}
+	}
+
+	v.references("", root)
+}
+
+func (v *validator) paths(paths *yaml.Node, responsesRequired bool) {
+	if !v.object("paths", paths, true) {
+		return
+	}
+	pairs(paths, func(key string, item *yaml.Node) {
+		path := child("paths", key)
+		if strings.HasPrefix(key, "x-") {
+			return
+		}
+		if !strings.HasPrefix(key, "/") {
+			v.add(path, "must start with \"/\"")
+			return
+		}
+		v.pathItem(path, item, responsesRequired)
+	})
+}
+
+func (v *validator) pathItem(path string, item *yaml.Node, responsesRequired bool) {
+	if !v.object(path, item, true) {
+		return
+	}
+	v.servers(child(path, "servers"), field(item, "servers"))
+	v.parameters(child(path, "parameters"), field(item, "parameters"))
+
+	pairs(item, func(key string, value *yaml.Node) {
+		switch {
+		case pathItemFields[key], strings.HasPrefix(key, "x-"):
+		case isMethod(key):
+			v.operation(child(path, key), value, responsesRequired)
+		default:
+			v.add(child(path, key), "is not a known path item field")
+		}
+	})
+}
+
+func (v *validator) operation(path string, operation *yaml.Node, responsesRequired bool) {
+	if !v.object(path, operation, true) {
+		return
+	}
+
+	if operationID := field(operation, "operationId"); operationID != nil {
+		id, ok := stringValue(operationID)
+		switch {
+		case !ok || id == "":
+			v.add(child(path, "operationId"), "must be a non-empty string")
+		case v.operationIDs[id] != "":
+			v.add(child(path, "operationId"), "duplicates the operationId of %s", v.operationIDs[id])
+		default:
+			v.operationIDs[id] = path
+		}
+	}
+
+	v.parameters(child(path, "parameters"), field(operation, "parameters"))
+	v.servers(child(path, "servers"), field(operation, "servers"))
+
+	if body := field(operation, "requestBody"); v.object(child(path, "requestBody"), body, false) && field(body, "$ref") == nil {
+		v.object(child(child(path, "requestBody"), "content"), field(body, "content"), true)
+	}
+
+	responses := field(operation, "responses")
+	if responses == nil {
+		if responsesRequired {
+			v.add(child(path, "responses"), "is required")
+		}
+		return
+	}
+	v.responses(child(path, "responses"), responses)
+}
+
+func (v *validator) responses(path string, responses *yaml.Node) {
+	if !v.object(path, responses, true) {
+		return
+	}
+	if len(responses.Content) == 0 {
+		v.add(path, "must define at least one response")
+	}
+	pairs(responses, func(code string, response *yaml.Node) {
+		responsePath := child(path, code)
+		if code != "default" && !strings.HasPrefix(code, "x-") && !statusCodePattern.MatchString(code) {
+			v.add(responsePath, "must be \"default\", an HTTP status code or a range such as \"4XX\"")
+			return
+		}
+		v.response(responsePath, response)
+	})
+}
+
+func (v *validator) response(path string, response *yaml.Node) {
+	if v.object(path, response, true) && field(response, "$ref") == nil {
+		v.requiredString(child(path, "description"), field(response, "description"))
+	}
+}
+
+func (v *validator) parameters(path string, parameters *yaml.Node) {
+	if !v.array(path, parameters) {
+		return
+	}
+	for i, parameter := range parameters.Content {
+		v.parameter(index(path, i), resolve(parameter))
+	}
+}
+
+func (v *validator) parameter(path string, parameter *yaml.Node) {
+	if !v.object(path, parameter, true) || field(parameter, "$ref") != nil {
+		return
+	}
+	v.requiredString(child(path, "name"), field(parameter, "name"))
+
+	in, ok := stringValue(field(parameter, "in"))
+	if !ok {
+		v.add(child(path, "in"), "is required")
+		return
+	}
+	if !parameterLocations[in] {
+		v.add(child(path, "in"), "must be one of query, header, path, cookie")
+		return
+	}
+	if in == "path" && !isTrue(field(parameter, "required")) {
+		v.add(child(path, "required"), "must be true for path parameters")
+	}
+}
+
+func (v *validator) servers(path string, servers *yaml.Node) {
+	if !v.array(path, servers) {
+		return
+	}
+	for i, server := range servers.Content {
+		serverPath := index(path, i)
+		if v.object(serverPath, server, true) {
+			v.requiredString(child(serverPath, "url"), field(server, "url"))
+		}
+	}
+}
+
+func (v *validator) components(components *yaml.Node, responsesRequired bool) {
+	for _, componentType := range componentTypes {
+		path := child("components", componentType)
+		objects := field(components, componentType)
+		if !v.object(path, objects, false) {
+			continue
+		}
+		pairs(objects, func(name string, object *yaml.Node) {
+			objectPath := child(path, name)
+			if !componentNamePattern.MatchString(name) {
+				v.add(objectPath, "must only contain letters, digits, \".\", \"-\" and \"_\"")
+				return
+			}
+			switch componentType {
+			case "responses":
+				v.response(objectPath, object)
+			case "parameters":
+				v.parameter(objectPath, object)
+			case "pathItems":
+				v.pathItem(objectPath, object, responsesRequired)
+			default:
+				v.object(objectPath, object, true)
+			}
+		})
+	}
+}
+
+// references checks that every local "$ref" below node, such as
+// "#/components/schemas/Pet", points at a value in the document. Other
+// references are left alone.
+func (v *validator) references(path string, node *yaml.Node) {
+	node = resolve(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		pairs(node, func(key string, value *yaml.Node) {
+			ref, ok := stringValue(value)
+			if key == "$ref" && ok {
+				if strings.HasPrefix(ref, "#") && v.doc.pointer(ref) == nil {
+					v.add(child(path, key), "references %q, which does not exist", ref)
+				}
+				return
+			}
+			v.references(child(path, key), value)
+		})
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.references(index(path, i), item)
+		}
+	}
+}
+
+// object reports whether node is a mapping, adding a violation when it is
+// not. A missing node is a violation only when required.
+func (v *validator) object(path string, node *yaml.Node, required bool) bool {
+	if node == nil {
+		if required {
+			v.add(path, "is required")
+		}
+		return false
+	}
+	if resolve(node).Kind != yaml.MappingNode {
+		v.add(path, "must be an object")
+		return false
+	}
+	return true
+}
+
+// array reports whether node is a sequence, adding a violation when it is
+// present but not one.
+func (v *validator) array(path string, node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	if resolve(node).Kind != yaml.SequenceNode {
+		v.add(path, "must be an array")
+		return false
+	}
+	return true
+}
+
+func (v *validator) requiredString(path string, node *yaml.Node) {
+	if node == nil {
+		v.add(path, "is required")
+		return
+	}
+	if _, ok := stringValue(node); !ok {
+		v.add(path, "must be a string")
+	}
+}
+
+// minor returns the minor version of the document, or -1 when its openapi
+// field is not a 3.x version.
+func (d *Document) minor() int {
+	match := versionPattern.FindStringSubmatch(d.Version)
+	if match == nil {
+		return -1
+	}
+	minor, _ := strconv.Atoi(match[1])
+	return minor
+}
+
+// pointer resolves a local reference such as "#/paths/~1pets/get" to the
+// value it points at, or nil when there is none.
+func (d *Document) pointer(ref string) *yaml.Node {
+	fragment, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return nil
+	}
+	node := d.root
+	if fragment == "" {
+		return node
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil
+	}
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = field(node, token)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = resolve(node.Content[i])
+		default:
+			return nil
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func isMethod(key string) bool {
+	for _, method := range Methods {
+		if key == method {
+			return true
+		}
+	}
+	return false
+}
+
+func isTrue(node *yaml.Node) bool {
+	var value bool
+	node = resolve(node)
+	return node != nil && node.ShortTag() == "!!bool" && node.Decode(&value) == nil && value
+}
+
+func child(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func index(path string, i int) string {
+	return child(path, strconv.Itoa(i))
+}
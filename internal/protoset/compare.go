@@ -0,0 +1,297 @@
+package protoset
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ChangeKind identifies what changed between two sets.
+type ChangeKind string
+
+const (
+	ServiceAdded           ChangeKind = "service-added"
+	ServiceRemoved         ChangeKind = "service-removed"
+	MethodAdded            ChangeKind = "method-added"
+	MethodRemoved          ChangeKind = "method-removed"
+	MethodTypeChanged      ChangeKind = "method-type-changed"
+	MethodStreamingChanged ChangeKind = "method-streaming-changed"
+	MessageAdded           ChangeKind = "message-added"
+	MessageRemoved         ChangeKind = "message-removed"
+	FieldAdded             ChangeKind = "field-added"
+	FieldRemoved           ChangeKind = "field-removed"
+	FieldNumberChanged     ChangeKind = "field-number-changed"
+	FieldTypeChanged       ChangeKind = "field-type-changed"
+	FieldCardinality       ChangeKind = "field-cardinality-changed"
+	FieldRenamed           ChangeKind = "field-renamed"
+	EnumAdded              ChangeKind = "enum-added"
+	EnumRemoved            ChangeKind = "enum-removed"
+	EnumValueAdded         ChangeKind = "enum-value-added"
+	EnumValueRemoved       ChangeKind = "enum-value-removed"
+)
+
+// Change is a difference between two versions of a set. Element is the
+// fully qualified name of what changed, e.g. "acme.pets.v1.Pet.name" or
+// "acme.pets.v1.PetService.GetPet". Breaking changes can make peers built
+// against the old version misread messages or fail calls.
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Element  string     `json:"element"`
+	Message  string     `json:"message"`
+	Breaking bool       `json:"breaking"`
+}
+
+// HasBreaking reports whether any of changes is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare lists the changes from one version of a set to the next: first
+// services and methods, then messages and their fields, then enums and
+// their values, each sorted by name. Only what affects the binary wire
+// format is breaking: removed services and methods, changed method types,
+// fields removed without reserving their number, changed field numbers,
+// types and cardinality, and enum values removed without reserving their
+// number. Removed messages and enums and renamed fields are reported as
+// non-breaking, since the wire format does not carry names.
+func Compare(from, to *Set) []Change {
+	var c comparer
+	c.services(from, to)
+	c.messages(from, to)
+	c.enums(from, to)
+	return c.changes
+}
+
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) add(kind ChangeKind, element protoreflect.FullName, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Kind:     kind,
+		Element:  string(element),
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (c *comparer) services(from, to *Set) {
+	toServices := map[protoreflect.FullName]protoreflect.ServiceDescriptor{}
+	for _, service := range to.services() {
+		toServices[service.FullName()] = service
+	}
+	fromServices := map[protoreflect.FullName]bool{}
+
+	for _, fromService := range from.services() {
+		name := fromService.FullName()
+		fromServices[name] = true
+		toService, ok := toServices[name]
+		if !ok {
+			c.add(ServiceRemoved, name, true, "service %s was removed", name)
+			continue
+		}
+		c.methods(fromService.Methods(), toService.Methods())
+	}
+	for _, toService := range to.services() {
+		if !fromServices[toService.FullName()] {
+			c.add(ServiceAdded, toService.FullName(), false, "service %s was added", toService.FullName())
+		}
+	}
+}
+
+func (c *comparer) methods(from, to protoreflect.MethodDescriptors) {
+	for i := 0; i < from.Len(); i++ {
+		fromMethod := from.Get(i)
+		name := fromMethod.FullName()
+		toMethod := to.ByName(fromMethod.Name())
+		if toMethod == nil {
+			c.add(MethodRemoved, name, true, "method %s was removed", name)
+			continue
+		}
+		if fromMethod.Input().FullName() != toMethod.Input().FullName() {
+			c.add(MethodTypeChanged, name, true, "request type changed from %s to %s",
+				fromMethod.Input().FullName(), toMethod.Input().FullName())
+		}
+		if fromMethod.Output().FullName() != toMethod.Output().FullName() {
+			c.add(MethodTypeChanged, name, true, "response type changed from %s to %s",
+				fromMethod.Output().FullName(), toMethod.Output().FullName())
+		}
+		if fromMethod.IsStreamingClient() != toMethod.IsStreamingClient() ||
+			fromMethod.IsStreamingServer() != toMethod.IsStreamingServer() {
+			c.add(MethodStreamingChanged, name, true, "streaming changed from %s to %s",
+				streaming(fromMethod), streaming(toMethod))
+		}
+	}
+	for i := 0; i < to.Len(); i++ {
+		if from.ByName(to.Get(i).Name()) == nil {
+			c.add(MethodAdded, to.Get(i).FullName(), false, "method %s was added", to.Get(i).FullName())
+		}
+	}
+}
+
+func (c *comparer) messages(from, to *Set) {
+	fromNames, fromMessages := from.messages()
+	toNames, toMessages := to.messages()
+
+	for _, name := range fromNames {
+		toMessage, ok := toMessages[name]
+		if !ok {
+			c.add(MessageRemoved, name, false, "message %s was removed", name)
+			continue
+		}
+		c.fields(fromMessages[name], toMessage)
+	}
+	for _, name := range toNames {
+		if _, ok := fromMessages[name]; !ok {
+			c.add(MessageAdded, name, false, "message %s was added", name)
+		}
+	}
+}
+
+// fields compares the fields of a message by number, which is what
+// identifies them on the wire.
+func (c *comparer) fields(from, to protoreflect.MessageDescriptor) {
+	fromFields := from.Fields()
+	toFields := to.Fields()
+
+	for i := 0; i < fromFields.Len(); i++ {
+		fromField := fromFields.Get(i)
+		name := fromField.FullName()
+		toField := toFields.ByNumber(fromField.Number())
+		if toField == nil {
+			if moved := toFields.ByName(fromField.Name()); moved != nil {
+				c.add(FieldNumberChanged, name, true, "field number changed from %d to %d", fromField.Number(), moved.Number())
+			} else if to.ReservedRanges().Has(fromField.Number()) {
+				c.add(FieldRemoved, name, false, "field %d was removed and its number reserved", fromField.Number())
+			} else {
+				c.add(FieldRemoved, name, true, "field %d was removed without reserving its number", fromField.Number())
+			}
+			continue
+		}
+
+		if fromField.Name() != toField.Name() {
+			c.add(FieldRenamed, name, false, "field %d was renamed to %s", fromField.Number(), toField.Name())
+		}
+		if fromType, toType := fieldType(fromField), fieldType(toField); fromType != toType {
+			compatible := wireGroup(fromField) != "" && wireGroup(fromField) == wireGroup(toField)
+			c.add(FieldTypeChanged, name, !compatible, "type changed from %s to %s", fromType, toType)
+		}
+		if fromCardinality, toCardinality := cardinality(fromField), cardinality(toField); fromCardinality != toCardinality {
+			c.add(FieldCardinality, name, true, "cardinality changed from %s to %s", fromCardinality, toCardinality)
+		}
+	}
+
+	for i := 0; i < toFields.Len(); i++ {
+		toField := toFields.Get(i)
+		if fromFields.ByNumber(toField.Number()) == nil && fromFields.ByName(toField.Name()) == nil {
+			c.add(FieldAdded, toField.FullName(), false, "field %d was added", toField.Number())
+		}
+	}
+}
+
+func (c *comparer) enums(from, to *Set) {
+	fromNames, fromEnums := from.enums()
+	toNames, toEnums := to.enums()
+
+	for _, name := range fromNames {
+		toEnum, ok := toEnums[name]
+		if !ok {
+			c.add(EnumRemoved, name, false, "enum %s was removed", name)
+			continue
+		}
+		fromValues := fromEnums[name].Values()
+		for i := 0; i < fromValues.Len(); i++ {
+			value := fromValues.Get(i)
+			if toEnum.Values().ByNumber(value.Number()) != nil {
+				continue
+			}
+			if toEnum.ReservedRanges().Has(value.Number()) {
+				c.add(EnumValueRemoved, value.FullName(), false, "enum value %d was removed and its number reserved", value.Number())
+			} else {
+				c.add(EnumValueRemoved, value.FullName(), true, "enum value %d was removed without reserving its number", value.Number())
+			}
+		}
+		toValues := toEnum.Values()
+		for i := 0; i < toValues.Len(); i++ {
+			if fromValues.ByNumber(toValues.Get(i).Number()) == nil {
+				c.add(EnumValueAdded, toValues.Get(i).FullName(), false, "enum value %d was added", toValues.Get(i).Number())
+			}
+		}
+	}
+	for _, name := range toNames {
+		if _, ok := fromEnums[name]; !ok {
+			c.add(EnumAdded, name, false, "enum %s was added", name)
+		}
+	}
+}
+
+// fieldType describes the type of a field: its kind, with the full name
+// of message and enum types, and the key and value types of maps.
+func fieldType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return "map<" + fieldType(field.MapKey()) + ", " + fieldType(field.MapValue()) + ">"
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
+
+// wireGroup returns the group of scalar kinds a field belongs to whose
+// values are encoded the same way on the wire, so changing between them
+// keeps old peers reading the field, or "" for other kinds.
+func wireGroup(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return ""
+	}
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Uint64Kind,
+		protoreflect.BoolKind, protoreflect.EnumKind:
+		return "varint"
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return "zigzag"
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		return "fixed32"
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		return "fixed64"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "length-delimited"
+	default:
+		return ""
+	}
+}
+
+// cardinality describes whether a field is repeated or singular, and
+// whether proto2 fields are required.
+func cardinality(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap(), field.Cardinality() == protoreflect.Repeated:
+		return "repeated"
+	case field.Cardinality() == protoreflect.Required:
+		return "required"
+	default:
+		return "singular"
+	}
+}
+
+func streaming(method protoreflect.MethodDescriptor) string {
+	switch {
+	case method.IsStreamingClient() && method.IsStreamingServer():
+		return "bidirectional"
+	case method.IsStreamingClient():
+		return "client streaming"
+	case method.IsStreamingServer():
+		return "server streaming"
+	default:
+		return "unary"
+	}
+}
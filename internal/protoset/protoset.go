@@ -0,0 +1,162 @@
+// Package protoset reads compiled protobuf FileDescriptorSets, as written
+// by "protoc --descriptor_set_out", lists the gRPC services they define
+// and checks two versions of a set for wire compatibility.
+package protoset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrInvalidSet is returned for data that is not a valid FileDescriptorSet.
+var ErrInvalidSet = errors.New("invalid FileDescriptorSet")
+
+// Set is a parsed FileDescriptorSet.
+type Set struct {
+	files *protoregistry.Files
+}
+
+// Parse reads a binary FileDescriptorSet. Imports missing from the set,
+// when it was compiled without --include_imports, are allowed; fields of
+// their types are compared by type name only.
+func Parse(data []byte) (*Set, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSet, err)
+	}
+	if len(set.File) == 0 {
+		return nil, fmt.Errorf("%w: the set contains no files", ErrInvalidSet)
+	}
+	files, err := protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSet, err)
+	}
+	return &Set{files: files}, nil
+}
+
+// Service is a gRPC service defined in a set.
+type Service struct {
+	Name    string   `json:"name"`
+	File    string   `json:"file"`
+	Methods []Method `json:"methods"`
+}
+
+// Method is an RPC of a Service. Input and output types are fully
+// qualified message names.
+type Method struct {
+	Name            string `json:"name"`
+	InputType       string `json:"input_type"`
+	OutputType      string `json:"output_type"`
+	ClientStreaming bool   `json:"client_streaming"`
+	ServerStreaming bool   `json:"server_streaming"`
+}
+
+// Files returns the paths of the files in the set, sorted.
+func (s *Set) Files() []string {
+	var paths []string
+	s.files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		paths = append(paths, file.Path())
+		return true
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// Services returns the services defined in the set sorted by fully
+// qualified name, with their methods in declaration order.
+func (s *Set) Services() []Service {
+	services := []Service{}
+	for _, service := range s.services() {
+		methods := service.Methods()
+		result := Service{
+			Name:    string(service.FullName()),
+			File:    service.ParentFile().Path(),
+			Methods: make([]Method, 0, methods.Len()),
+		}
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			result.Methods = append(result.Methods, Method{
+				Name:            string(method.Name()),
+				InputType:       string(method.Input().FullName()),
+				OutputType:      string(method.Output().FullName()),
+				ClientStreaming: method.IsStreamingClient(),
+				ServerStreaming: method.IsStreamingServer(),
+			})
+		}
+		services = append(services, result)
+	}
+	return services
+}
+
+// services returns the service descriptors of the set sorted by name.
+func (s *Set) services() []protoreflect.ServiceDescriptor {
+	var services []protoreflect.ServiceDescriptor
+	s.files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		for i := 0; i < file.Services().Len(); i++ {
+			services = append(services, file.Services().Get(i))
+		}
+		return true
+	})
+	sort.Slice(services, func(i, j int) bool { return services[i].FullName() < services[j].FullName() })
+	return services
+}
+
+// messages returns every message of the set, nested ones included, keyed
+// by fully qualified name, and the names sorted.
+func (s *Set) messages() ([]protoreflect.FullName, map[protoreflect.FullName]protoreflect.MessageDescriptor) {
+	var names []protoreflect.FullName
+	messages := map[protoreflect.FullName]protoreflect.MessageDescriptor{}
+	var add func(protoreflect.MessageDescriptors)
+	add = func(list protoreflect.MessageDescriptors) {
+		for i := 0; i < list.Len(); i++ {
+			message := list.Get(i)
+			// Map entries are compared as the map field itself
+			if message.IsMapEntry() {
+				continue
+			}
+			names = append(names, message.FullName())
+			messages[message.FullName()] = message
+			add(message.Messages())
+		}
+	}
+	s.files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		add(file.Messages())
+		return true
+	})
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names, messages
+}
+
+// enums returns every enum of the set, nested ones included, keyed by
+// fully qualified name, and the names sorted.
+func (s *Set) enums() ([]protoreflect.FullName, map[protoreflect.FullName]protoreflect.EnumDescriptor) {
+	var names []protoreflect.FullName
+	enums := map[protoreflect.FullName]protoreflect.EnumDescriptor{}
+	addEnums := func(list protoreflect.EnumDescriptors) {
+		for i := 0; i < list.Len(); i++ {
+			names = append(names, list.Get(i).FullName())
+			enums[list.Get(i).FullName()] = list.Get(i)
+		}
+	}
+	var addMessages func(protoreflect.MessageDescriptors)
+	addMessages = func(list protoreflect.MessageDescriptors) {
+		for i := 0; i < list.Len(); i++ {
+			addEnums(list.Get(i).Enums())
+			addMessages(list.Get(i).Messages())
+		}
+	}
+	s.files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		addEnums(file.Enums())
+		addMessages(file.Messages())
+		return true
+	})
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names, enums
+}
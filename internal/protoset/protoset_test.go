@@ -0,0 +1,230 @@
+package protoset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func field(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     kind.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func method(name, input, output string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String(name),
+		InputType:       proto.String(input),
+		OutputType:      proto.String(output),
+		ServerStreaming: proto.Bool(serverStreaming),
+	}
+}
+
+func enumValue(name string, number int32) *descriptorpb.EnumValueDescriptorProto {
+	return &descriptorpb.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(number)}
+}
+
+const (
+	typeString = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	typeBytes  = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	typeInt32  = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	typeInt64  = descriptorpb.FieldDescriptorProto_TYPE_INT64
+	typeDouble = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+	typeEnum   = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+)
+
+// petsV1 and petsV2 return a pets API before and after a set of changes.
+func petsV1() *descriptorpb.FileDescriptorSet {
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:       proto.String("acme/pets/v1/pets.proto"),
+		Package:    proto.String("acme.pets.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Pet"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, typeString, ""),
+					field("name", 2, typeString, ""),
+					field("kind", 3, typeEnum, ".acme.pets.v1.Kind"),
+					field("age", 4, typeInt32, ""),
+					field("weight", 5, typeDouble, ""),
+					field("tag", 6, typeString, ""),
+					field("nickname", 7, typeString, ""),
+					field("born", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				},
+			},
+			{Name: proto.String("GetPetRequest"), Field: []*descriptorpb.FieldDescriptorProto{field("id", 1, typeString, "")}},
+			{Name: proto.String("ListPetsRequest")},
+			{Name: proto.String("ListPetsResponse"), Field: []*descriptorpb.FieldDescriptorProto{
+				repeated(field("pets", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".acme.pets.v1.Pet")),
+			}},
+			{Name: proto.String("Legacy")},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:  proto.String("Kind"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{enumValue("KIND_UNSPECIFIED", 0), enumValue("KIND_CAT", 1), enumValue("KIND_DOG", 2), enumValue("KIND_FISH", 3)},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("PetService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					method("GetPet", ".acme.pets.v1.GetPetRequest", ".acme.pets.v1.Pet", false),
+					method("ListPets", ".acme.pets.v1.ListPetsRequest", ".acme.pets.v1.ListPetsResponse", false),
+					method("DeletePet", ".acme.pets.v1.GetPetRequest", ".acme.pets.v1.Pet", false),
+				},
+			},
+			{
+				Name:   proto.String("StoreService"),
+				Method: []*descriptorpb.MethodDescriptorProto{method("ListStores", ".acme.pets.v1.ListPetsRequest", ".acme.pets.v1.ListPetsResponse", false)},
+			},
+		},
+	}}}
+}
+
+func petsV2() *descriptorpb.FileDescriptorSet {
+	set := petsV1()
+	file := set.File[0]
+
+	pet := file.MessageType[0]
+	pet.Field = []*descriptorpb.FieldDescriptorProto{
+		field("id", 1, typeBytes, ""),         // string to bytes: compatible
+		field("full_name", 2, typeString, ""), // renamed
+		field("kind", 3, typeEnum, ".acme.pets.v1.Kind"),
+		field("age", 4, typeInt64, ""),            // int32 to int64: compatible
+		field("weight", 5, typeString, ""),        // double to string: breaking
+		repeated(field("tag", 6, typeString, "")), // singular to repeated
+		field("nickname", 9, typeString, ""),      // renumbered
+		field("owner", 10, typeString, ""),        // added
+	}
+	// The removed born field had its number reserved
+	pet.ReservedRange = []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(8), End: proto.Int32(9)}}
+	file.Dependency = nil
+
+	file.MessageType = append(file.MessageType[:4], &descriptorpb.DescriptorProto{Name: proto.String("Owner")})
+
+	kind := file.EnumType[0]
+	kind.Value = []*descriptorpb.EnumValueDescriptorProto{enumValue("KIND_UNSPECIFIED", 0), enumValue("KIND_CAT", 1), enumValue("KIND_BIRD", 4)}
+	kind.ReservedRange = []*descriptorpb.EnumDescriptorProto_EnumReservedRange{{Start: proto.Int32(3), End: proto.Int32(3)}}
+
+	file.Service = file.Service[:1]
+	file.Service[0].Method = []*descriptorpb.MethodDescriptorProto{
+		method("GetPet", ".acme.pets.v1.GetPetRequest", ".acme.pets.v1.Owner", false),
+		method("ListPets", ".acme.pets.v1.ListPetsRequest", ".acme.pets.v1.ListPetsResponse", true),
+		method("WatchPets", ".acme.pets.v1.ListPetsRequest", ".acme.pets.v1.Pet", true),
+	}
+	return set
+}
+
+func parse(t *testing.T, set *descriptorpb.FileDescriptorSet) *Set {
+	t.Helper()
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestParse(t *testing.T) {
+	set := parse(t, petsV1())
+
+	assert.Equal(t, []string{"acme/pets/v1/pets.proto"}, set.Files())
+	services := set.Services()
+	require.Len(t, services, 2)
+	assert.Equal(t, "acme.pets.v1.PetService", services[0].Name)
+	assert.Equal(t, "acme/pets/v1/pets.proto", services[0].File)
+	assert.Equal(t, []Method{
+		{Name: "GetPet", InputType: "acme.pets.v1.GetPetRequest", OutputType: "acme.pets.v1.Pet"},
+		{Name: "ListPets", InputType: "acme.pets.v1.ListPetsRequest", OutputType: "acme.pets.v1.ListPetsResponse"},
+		{Name: "DeletePet", InputType: "acme.pets.v1.GetPetRequest", OutputType: "acme.pets.v1.Pet"},
+	}, services[0].Methods)
+	assert.Equal(t, "acme.pets.v1.StoreService", services[1].Name)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not protobuf", []byte("openapi: 3.0.0")},
+		{"empty set", nil},
+		{"duplicate message", func() []byte {
+			set := petsV1()
+			set.File[0].MessageType[1].Name = proto.String("Pet")
+			data, _ := proto.Marshal(set)
+			return data
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			assert.ErrorIs(t, err, ErrInvalidSet)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	changes := Compare(parse(t, petsV1()), parse(t, petsV2()))
+
+	type summary struct {
+		Kind     ChangeKind
+		Element  string
+		Breaking bool
+	}
+	var got []summary
+	for _, change := range changes {
+		got = append(got, summary{change.Kind, change.Element, change.Breaking})
+	}
+
+	const pkg = "acme.pets.v1."
+	assert.Equal(t, []summary{
+		{MethodTypeChanged, pkg + "PetService.GetPet", true},
+		{MethodStreamingChanged, pkg + "PetService.ListPets", true},
+		{MethodRemoved, pkg + "PetService.DeletePet", true},
+		{MethodAdded, pkg + "PetService.WatchPets", false},
+		{ServiceRemoved, pkg + "StoreService", true},
+		{MessageRemoved, pkg + "Legacy", false},
+		{FieldTypeChanged, pkg + "Pet.id", false},
+		{FieldRenamed, pkg + "Pet.name", false},
+		{FieldTypeChanged, pkg + "Pet.age", false},
+		{FieldTypeChanged, pkg + "Pet.weight", true},
+		{FieldCardinality, pkg + "Pet.tag", true},
+		{FieldNumberChanged, pkg + "Pet.nickname", true},
+		{FieldRemoved, pkg + "Pet.born", false},
+		{FieldAdded, pkg + "Pet.owner", false},
+		{MessageAdded, pkg + "Owner", false},
+		{EnumValueRemoved, pkg + "KIND_DOG", true},
+		{EnumValueRemoved, pkg + "KIND_FISH", false},
+		{EnumValueAdded, pkg + "KIND_BIRD", false},
+	}, got)
+
+	assert.Equal(t, "response type changed from acme.pets.v1.Pet to acme.pets.v1.Owner", changes[0].Message)
+	assert.Equal(t, "streaming changed from unary to server streaming", changes[1].Message)
+	assert.Equal(t, "field number changed from 7 to 9", changes[11].Message)
+	assert.True(t, HasBreaking(changes))
+}
+
+func TestCompareIdentical(t *testing.T) {
+	set := parse(t, petsV1())
+
+	changes := Compare(set, set)
+	assert.Empty(t, changes)
+	assert.False(t, HasBreaking(changes))
+}
@@ -0,0 +1,243 @@
+// Package resolver picks one version of each of a set of services such that
+// every picked version's requirements on the others are satisfied, or
+// explains why no such set exists.
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"serviceCatalog/internal/semver"
+)
+
+// ErrTooComplex is returned when resolution gives up after the step limit.
+var ErrTooComplex = errors.New("too many combinations to try")
+
+// Requirement is a range of versions of another service that a version
+// needs.
+type Requirement struct {
+	ServiceID uint
+	Service   string
+	Range     *semver.Constraint
+}
+
+// Candidate is a version that may be picked for its service.
+type Candidate struct {
+	ServiceID uint
+	Service   string
+	VersionID uint
+	Number    string
+	Version   semver.Version
+	Requires  []Requirement
+}
+
+// String identifies the candidate as service@number.
+func (c *Candidate) String() string {
+	return c.Service + "@" + c.Number
+}
+
+// Service is a service to pick a version of, with its candidate versions
+// most preferred first.
+type Service struct {
+	ID         uint
+	Name       string
+	Candidates []Candidate
+}
+
+// Source loads a service and its candidate versions.
+type Source func(serviceID uint) (*Service, error)
+
+// Demand is a range of versions of a service required by a picked version.
+type Demand struct {
+	By    *Candidate
+	Range *semver.Constraint
+}
+
+// Rejection is a candidate that could not be picked and why.
+type Rejection struct {
+	Candidate *Candidate
+	Reason    string
+}
+
+// Conflict explains why no version of a service could be picked given the
+// versions picked for the others: the ranges they demand of it and the
+// reason each of its candidates was rejected.
+type Conflict struct {
+	ServiceID uint
+	Service   string
+	Demands   []Demand
+	Rejected  []Rejection
+}
+
+// Message summarizes the conflict in one sentence.
+func (c *Conflict) Message() string {
+	if len(c.Rejected) == 0 {
+		return fmt.Sprintf("%s has no versions to pick from", c.Service)
+	}
+	var demands []string
+	for _, demand := range c.Demands {
+		demands = append(demands, fmt.Sprintf("%s requires %s", demand.By, demand.Range))
+	}
+	if len(demands) == 0 {
+		return fmt.Sprintf("no version of %s is compatible with the other versions picked", c.Service)
+	}
+	return fmt.Sprintf("no version of %s satisfies every requirement: %s", c.Service, strings.Join(demands, ", "))
+}
+
+// Result is the outcome of a resolution. Either Versions lists the picked
+// versions, requested services first in the order given followed by the
+// services they pulled in, or Conflict explains the failure.
+type Result struct {
+	Versions []*Candidate
+	Conflict *Conflict
+}
+
+// Resolve picks a version of each service in serviceIDs and of every
+// service a picked version requires. Candidates are tried in the order the
+// source returns them, backtracking when a later service cannot be
+// satisfied, so the first solution prefers the earliest candidates of the
+// services listed first. When there is no solution the conflict that was
+// reached with the most services picked is reported. Resolution gives up
+// with ErrTooComplex after trying maxSteps candidates.
+func Resolve(serviceIDs []uint, source Source, maxSteps int) (Result, error) {
+	r := &resolver{
+		source:   source,
+		maxSteps: maxSteps,
+		services: map[uint]*Service{},
+		picked:   map[uint]*Candidate{},
+	}
+
+	var pending []uint
+	seen := map[uint]bool{}
+	for _, id := range serviceIDs {
+		if !seen[id] {
+			seen[id] = true
+			pending = append(pending, id)
+		}
+	}
+
+	if r.solve(pending) {
+		return Result{Versions: r.order}, nil
+	}
+	if r.err != nil {
+		return Result{}, r.err
+	}
+	return Result{Conflict: r.conflict}, nil
+}
+
+type resolver struct {
+	source   Source
+	maxSteps int
+	steps    int
+	err      error
+
+	services map[uint]*Service
+	picked   map[uint]*Candidate
+	order    []*Candidate
+
+	conflict      *Conflict
+	conflictDepth int
+}
+
+// solve picks versions for the pending services in order, returning false
+// when no combination works or an error occurred.
+func (r *resolver) solve(pending []uint) bool {
+	for len(pending) > 0 && r.picked[pending[0]] != nil {
+		pending = pending[1:]
+	}
+	if len(pending) == 0 {
+		return true
+	}
+	serviceID, rest := pending[0], pending[1:]
+
+	service, err := r.load(serviceID)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	demands := r.demands(serviceID)
+
+	var rejected []Rejection
+	candidates := service.Candidates
+	for i := range candidates {
+		r.steps++
+		if r.steps > r.maxSteps {
+			r.err = ErrTooComplex
+			return false
+		}
+
+		candidate := &candidates[i]
+		if reason := r.reject(candidate, demands); reason != "" {
+			rejected = append(rejected, Rejection{Candidate: candidate, Reason: reason})
+			continue
+		}
+
+		r.picked[serviceID] = candidate
+		r.order = append(r.order, candidate)
+		next := append([]uint(nil), rest...)
+		for _, requirement := range candidate.Requires {
+			next = append(next, requirement.ServiceID)
+		}
+		if r.solve(next) {
+			return true
+		}
+		delete(r.picked, serviceID)
+		r.order = r.order[:len(r.order)-1]
+		if r.err != nil {
+			return false
+		}
+		rejected = append(rejected, Rejection{Candidate: candidate, Reason: "its requirements conflict with other services"})
+	}
+
+	// Keep the conflict reached with the most services picked, the one
+	// closest to a solution
+	if r.conflict == nil || len(r.picked) > r.conflictDepth {
+		r.conflict = &Conflict{ServiceID: serviceID, Service: service.Name, Demands: demands, Rejected: rejected}
+		r.conflictDepth = len(r.picked)
+	}
+	return false
+}
+
+// load returns a service and its candidates, asking the source once.
+func (r *resolver) load(serviceID uint) (*Service, error) {
+	if service, ok := r.services[serviceID]; ok {
+		return service, nil
+	}
+	service, err := r.source(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	r.services[serviceID] = service
+	return service, nil
+}
+
+// demands returns the ranges the picked versions require of a service, in
+// the order they were picked.
+func (r *resolver) demands(serviceID uint) []Demand {
+	var demands []Demand
+	for _, picked := range r.order {
+		for _, requirement := range picked.Requires {
+			if requirement.ServiceID == serviceID {
+				demands = append(demands, Demand{By: picked, Range: requirement.Range})
+			}
+		}
+	}
+	return demands
+}
+
+// reject returns why a candidate cannot be picked alongside the versions
+// picked so far, or "" when it can.
+func (r *resolver) reject(candidate *Candidate, demands []Demand) string {
+	for _, demand := range demands {
+		if !demand.Range.Check(candidate.Version) {
+			return fmt.Sprintf("%s requires %s", demand.By, demand.Range)
+		}
+	}
+	for _, requirement := range candidate.Requires {
+		if picked := r.picked[requirement.ServiceID]; picked != nil && !requirement.Range.Check(picked.Version) {
+			return fmt.Sprintf("requires %s %s but %s was picked", requirement.Service, requirement.Range, picked)
+		}
+	}
+	return ""
+}
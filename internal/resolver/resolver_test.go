@@ -0,0 +1,172 @@
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"serviceCatalog/internal/semver"
+)
+
+// version is a candidate in a test catalog, requiring ranges of other
+// services by name.
+type version struct {
+	number   string
+	requires map[string]string
+}
+
+// catalog builds a source over services identified by their position in
+// names, each with its versions most preferred first.
+func catalog(t *testing.T, names []string, versions map[string][]version) Source {
+	ids := map[string]uint{}
+	for i, name := range names {
+		ids[name] = uint(i + 1)
+	}
+	return func(serviceID uint) (*Service, error) {
+		name := names[serviceID-1]
+		service := &Service{ID: serviceID, Name: name}
+		for i, v := range versions[name] {
+			parsed, err := semver.Parse(v.number)
+			require.NoError(t, err)
+			candidate := Candidate{
+				ServiceID: serviceID,
+				Service:   name,
+				VersionID: serviceID*100 + uint(i),
+				Number:    v.number,
+				Version:   parsed,
+			}
+			for dependency, rangeValue := range v.requires {
+				constraint, err := semver.ParseConstraint(rangeValue)
+				require.NoError(t, err)
+				candidate.Requires = append(candidate.Requires, Requirement{
+					ServiceID: ids[dependency],
+					Service:   dependency,
+					Range:     constraint,
+				})
+			}
+			service.Candidates = append(service.Candidates, candidate)
+		}
+		return service, nil
+	}
+}
+
+func picked(result Result) []string {
+	var versions []string
+	for _, candidate := range result.Versions {
+		versions = append(versions, candidate.String())
+	}
+	return versions
+}
+
+var names = []string{"checkout", "payments", "ledger", "orders"}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []uint
+		versions map[string][]version
+		want     []string
+	}{
+		{
+			name:     "latest versions fit",
+			services: []uint{1, 2},
+			versions: map[string][]version{
+				"checkout": {{"3.1.0", map[string]string{"payments": ">=2.0.0 <3.0.0"}}, {"3.0.0", nil}},
+				"payments": {{"2.4.0", nil}, {"2.3.0", nil}},
+			},
+			want: []string{"checkout@3.1.0", "payments@2.4.0"},
+		},
+		{
+			name:     "older dependency picked",
+			services: []uint{1, 2},
+			versions: map[string][]version{
+				"checkout": {{"3.1.0", map[string]string{"payments": "^2.0.0"}}},
+				"payments": {{"3.0.0", nil}, {"2.4.0", nil}, {"1.0.0", nil}},
+			},
+			want: []string{"checkout@3.1.0", "payments@2.4.0"},
+		},
+		{
+			name:     "backtracks to an older consumer",
+			services: []uint{1, 4},
+			versions: map[string][]version{
+				"checkout": {
+					{"3.1.0", map[string]string{"payments": "^3.0.0"}},
+					{"3.0.0", map[string]string{"payments": "^2.0.0"}},
+				},
+				"payments": {{"3.0.0", nil}, {"2.4.0", nil}},
+				"orders":   {{"1.2.0", map[string]string{"payments": "~2.4"}}},
+			},
+			want: []string{"checkout@3.0.0", "orders@1.2.0", "payments@2.4.0"},
+		},
+		{
+			name:     "pulls in transitive dependencies",
+			services: []uint{1},
+			versions: map[string][]version{
+				"checkout": {{"3.1.0", map[string]string{"payments": "^2.0.0"}}},
+				"payments": {{"2.4.0", map[string]string{"ledger": ">=1.1.0"}}},
+				"ledger":   {{"1.0.0", nil}, {"1.5.0", nil}},
+			},
+			want: []string{"checkout@3.1.0", "payments@2.4.0", "ledger@1.5.0"},
+		},
+		{
+			name:     "duplicate services",
+			services: []uint{2, 2},
+			versions: map[string][]version{"payments": {{"2.4.0", nil}}},
+			want:     []string{"payments@2.4.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Resolve(tt.services, catalog(t, names, tt.versions), 1000)
+			require.NoError(t, err)
+			assert.Nil(t, result.Conflict)
+			assert.Equal(t, tt.want, picked(result))
+		})
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	versions := map[string][]version{
+		"checkout": {{"3.1.0", map[string]string{"payments": ">=2.0.0 <3.0.0"}}},
+		"payments": {{"3.0.0", nil}, {"2.4.0", nil}},
+		"orders":   {{"1.2.0", map[string]string{"payments": "^3.0.0"}}},
+	}
+
+	result, err := Resolve([]uint{1, 4}, catalog(t, names, versions), 1000)
+	require.NoError(t, err)
+	assert.Empty(t, result.Versions)
+	require.NotNil(t, result.Conflict)
+
+	conflict := result.Conflict
+	assert.Equal(t, uint(2), conflict.ServiceID)
+	assert.Equal(t, "payments", conflict.Service)
+	require.Len(t, conflict.Demands, 2)
+	assert.Equal(t, "checkout@3.1.0", conflict.Demands[0].By.String())
+	assert.Equal(t, "orders@1.2.0", conflict.Demands[1].By.String())
+	require.Len(t, conflict.Rejected, 2)
+	assert.Equal(t, "checkout@3.1.0 requires >=2.0.0 <3.0.0", conflict.Rejected[0].Reason)
+	assert.Equal(t, "orders@1.2.0 requires ^3.0.0", conflict.Rejected[1].Reason)
+	assert.Equal(t, "no version of payments satisfies every requirement: checkout@3.1.0 requires >=2.0.0 <3.0.0, orders@1.2.0 requires ^3.0.0", conflict.Message())
+}
+
+func TestResolveNoVersions(t *testing.T) {
+	result, err := Resolve([]uint{3}, catalog(t, names, nil), 1000)
+	require.NoError(t, err)
+	require.NotNil(t, result.Conflict)
+	assert.Equal(t, "ledger has no versions to pick from", result.Conflict.Message())
+}
+
+func TestResolveErrors(t *testing.T) {
+	versions := map[string][]version{
+		"checkout": {{"3.1.0", map[string]string{"payments": "^9.0.0"}}, {"3.0.0", map[string]string{"payments": "^9.0.0"}}},
+		"payments": {{"2.4.0", nil}},
+	}
+	_, err := Resolve([]uint{1}, catalog(t, names, versions), 3)
+	assert.ErrorIs(t, err, ErrTooComplex)
+
+	failing := errors.New("database is down")
+	_, err = Resolve([]uint{1}, func(uint) (*Service, error) { return nil, failing }, 1000)
+	assert.ErrorIs(t, err, failing)
+}
@@ -0,0 +1,188 @@
+// Package retention permanently removes soft-deleted services and their
+// versions, either on demand or periodically from a background worker.
+package retention
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"serviceCatalog/config"
+	"serviceCatalog/internal/models"
+	"time"
+)
+
+// PurgeResult counts the rows removed by a purge.
+type PurgeResult struct {
+	Services int64 `json:"services"`
+	Versions int64 `json:"versions"`
+}
+
+// serviceChildren are the rows, apart from versions, that belong to a
+// single service and are removed together with it.
+var serviceChildren = []interface{}{
+	&models.ServiceLabel{}, &models.ServiceLink{}, &models.LifecycleTransition{}, &models.ServiceDependency{},
+}
+
+// deleteServiceChildren removes the serviceChildren rows of the services
+// selected by serviceIDs, a list of IDs or a subquery, and the dependency
+// edges and version requirements pointing at them.
+func deleteServiceChildren(tx *gorm.DB, serviceIDs interface{}) error {
+	for _, child := range serviceChildren {
+		if err := tx.Where("service_id IN (?)", serviceIDs).Delete(child).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("depends_on_id IN (?)", serviceIDs).Delete(&models.ServiceDependency{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("depends_on_id IN (?)", serviceIDs).Delete(&models.VersionRequirement{}).Error
+}
+
+// versionChildren are the rows that belong to a single version and are
+// removed together with it.
+var versionChildren = []interface{}{
+	&models.VersionSpec{}, &models.VersionDescriptorSet{}, &models.VersionRequirement{},
+	&models.VersionSBOM{}, &models.SBOMComponent{},
+}
+
+// deleteVersionChildren removes the versionChildren rows of the versions
+// selected by versionIDs, a subquery.
+func deleteVersionChildren(tx *gorm.DB, versionIDs interface{}) error {
+	for _, child := range versionChildren {
+		if err := tx.Where("version_id IN (?)", versionIDs).Delete(child).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeService hard-deletes a service with its labels, links, lifecycle
+// history and all of its versions and their contracts, whether or not
+// they are soft-deleted, inside a single transaction.
+func PurgeService(db *gorm.DB, serviceID uint) (PurgeResult, error) {
+	var result PurgeResult
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteServiceChildren(tx, []uint{serviceID}); err != nil {
+			return err
+		}
+		versionIDs := tx.Unscoped().Model(&models.Version{}).Select("id").Where("service_id = ?", serviceID)
+		if err := deleteVersionChildren(tx, versionIDs); err != nil {
+			return err
+		}
+		versions := tx.Unscoped().Where("service_id = ?", serviceID).Delete(&models.Version{})
+		if versions.Error != nil {
+			return versions.Error
+		}
+		services := tx.Unscoped().Delete(&models.Service{}, serviceID)
+		if services.Error != nil {
+			return services.Error
+		}
+		result = PurgeResult{Services: services.RowsAffected, Versions: versions.RowsAffected}
+		return nil
+	})
+	if err != nil {
+		return PurgeResult{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"service_id": serviceID,
+		"services":   result.Services,
+		"versions":   result.Versions,
+	}).Info("Purged service")
+
+	return result, nil
+}
+
+// PurgeDeletedBefore hard-deletes every service soft-deleted before cutoff
+// together with its labels, links, lifecycle history and versions, as well
+// as versions soft-deleted on their own before cutoff. Contracts go with
+// their versions.
+func PurgeDeletedBefore(db *gorm.DB, cutoff time.Time) (PurgeResult, error) {
+	var result PurgeResult
+	err := db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&models.Service{}).
+			Select("id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+
+		if err := deleteServiceChildren(tx, expired); err != nil {
+			return err
+		}
+		const expiredVersions = "service_id IN (?) OR (deleted_at IS NOT NULL AND deleted_at < ?)"
+		versionIDs := tx.Unscoped().Model(&models.Version{}).Select("id").Where(expiredVersions, expired, cutoff)
+		if err := deleteVersionChildren(tx, versionIDs); err != nil {
+			return err
+		}
+		versions := tx.Unscoped().Where(expiredVersions, expired, cutoff).Delete(&models.Version{})
+		if versions.Error != nil {
+			return versions.Error
+		}
+		services := tx.Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Delete(&models.Service{})
+		if services.Error != nil {
+			return services.Error
+		}
+		result = PurgeResult{Services: services.RowsAffected, Versions: versions.RowsAffected}
+		return nil
+	})
+	if err != nil {
+		return PurgeResult{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cutoff":   cutoff,
+		"services": result.Services,
+		"versions": result.Versions,
+	}).Info("Purged expired soft-deleted rows")
+
+	return result, nil
+}
+
+// Worker periodically purges rows soft-deleted longer ago than the
+// configured retention period.
+type Worker struct {
+	db       *gorm.DB
+	period   time.Duration
+	interval time.Duration
+}
+
+// NewWorker creates a retention worker from the retention configuration.
+// A missing interval defaults to one hour.
+func NewWorker(db *gorm.DB, cfg config.RetentionConfig) *Worker {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Worker{db: db, period: cfg.Period, interval: interval}
+}
+
+// Start runs the worker in a background goroutine until ctx is cancelled.
+// It does nothing when the retention period is not positive.
+func (w *Worker) Start(ctx context.Context) {
+	if w.period <= 0 {
+		logrus.Info("Retention worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.runOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *Worker) runOnce(ctx context.Context) {
+	cutoff := time.Now().Add(-w.period)
+	if _, err := PurgeDeletedBefore(w.db.WithContext(ctx), cutoff); err != nil {
+		logrus.WithError(err).Error("Retention purge failed")
+	}
+}
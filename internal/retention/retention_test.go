@@ -0,0 +1,167 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"serviceCatalog/internal/models"
+)
+
+// RetentionTestSuite runs every test in a transaction that is rolled back
+// afterwards, so it can share the test database with the handler tests.
+type RetentionTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tx *gorm.DB
+}
+
+// fixture is a service with a row in every table that belongs to a
+// service or version, and a neighbour it depends on and is required by.
+type fixture struct {
+	service   models.Service
+	version   models.Version
+	neighbour models.Service
+	consumer  models.Version
+}
+
+func (s *RetentionTestSuite) SetupSuite() {
+	dsn := "host=localhost user=postgres password=postgres dbname=servicecatalog_test port=5432 sslmode=disable"
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.db = db
+
+	err = db.AutoMigrate(&models.Team{}, &models.Service{}, &models.Version{}, &models.ServiceLabel{}, &models.AttributeSchema{}, &models.ServiceLink{}, &models.LifecycleTransition{}, &models.ServiceDependency{}, &models.VersionSpec{}, &models.VersionDescriptorSet{}, &models.VersionRequirement{}, &models.VersionSBOM{}, &models.SBOMComponent{})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *RetentionTestSuite) SetupTest() {
+	s.tx = s.db.Begin()
+}
+
+func (s *RetentionTestSuite) TearDownTest() {
+	s.tx.Rollback()
+}
+
+// create inserts rows, failing the test on error.
+func (s *RetentionTestSuite) create(rows ...interface{}) {
+	for _, row := range rows {
+		if err := s.tx.Create(row).Error; err != nil {
+			s.T().Fatal(err)
+		}
+	}
+}
+
+// seed creates a fixture whose service slugs start with prefix.
+func (s *RetentionTestSuite) seed(prefix string) fixture {
+	f := fixture{
+		service:   models.Service{Name: prefix + " Target", Lifecycle: models.LifecycleRetired},
+		neighbour: models.Service{Name: prefix + " Neighbour", Lifecycle: models.LifecycleProduction},
+	}
+	s.create(&f.service, &f.neighbour)
+
+	f.version = models.Version{ServiceID: f.service.ID, Number: "1.0.0"}
+	f.consumer = models.Version{ServiceID: f.neighbour.ID, Number: "1.0.0"}
+	s.create(&f.version, &f.consumer)
+
+	s.create(
+		&models.ServiceLabel{ServiceID: f.service.ID, Key: "tier", Value: "backend"},
+		&models.ServiceLink{ServiceID: f.service.ID, Type: models.LinkRunbook, URL: "https://runbooks.example.com/target"},
+		&models.LifecycleTransition{ServiceID: f.service.ID, From: models.LifecycleProposed, To: models.LifecycleRetired, Actor: "test"},
+		&models.ServiceDependency{ServiceID: f.service.ID, DependsOnID: f.neighbour.ID, Kind: models.DependencySyncHTTP},
+		&models.ServiceDependency{ServiceID: f.neighbour.ID, DependsOnID: f.service.ID, Kind: models.DependencyAsyncQueue},
+		&models.VersionSpec{VersionID: f.version.ID, OpenAPI: "3.0.3", Format: "json", Document: `{"openapi": "3.0.3"}`},
+		&models.VersionDescriptorSet{VersionID: f.version.ID, Data: []byte{0x0a, 0x00}},
+		&models.VersionRequirement{VersionID: f.version.ID, DependsOnID: f.neighbour.ID, Range: "^1.0.0"},
+		&models.VersionRequirement{VersionID: f.consumer.ID, DependsOnID: f.service.ID, Range: "^1.0.0"},
+		&models.VersionSBOM{VersionID: f.version.ID, Format: "cyclonedx", SpecVersion: "1.5"},
+		&models.SBOMComponent{VersionID: f.version.ID, PURL: "pkg:npm/left-pad@1.3.0", PURLType: "npm", PURLName: "left-pad", Name: "left-pad", Version: "1.3.0"},
+	)
+	return f
+}
+
+// assertPurged checks that no row of any table belongs to or points at
+// the given services and versions.
+func (s *RetentionTestSuite) assertPurged(serviceIDs, versionIDs []uint) {
+	checks := []struct {
+		model  interface{}
+		column string
+		ids    []uint
+	}{
+		{&models.Service{}, "id", serviceIDs},
+		{&models.Version{}, "id", versionIDs},
+		{&models.Version{}, "service_id", serviceIDs},
+		{&models.ServiceLabel{}, "service_id", serviceIDs},
+		{&models.ServiceLink{}, "service_id", serviceIDs},
+		{&models.LifecycleTransition{}, "service_id", serviceIDs},
+		{&models.ServiceDependency{}, "service_id", serviceIDs},
+		{&models.ServiceDependency{}, "depends_on_id", serviceIDs},
+		{&models.VersionSpec{}, "version_id", versionIDs},
+		{&models.VersionDescriptorSet{}, "version_id", versionIDs},
+		{&models.VersionRequirement{}, "version_id", versionIDs},
+		{&models.VersionRequirement{}, "depends_on_id", serviceIDs},
+		{&models.VersionSBOM{}, "version_id", versionIDs},
+		{&models.SBOMComponent{}, "version_id", versionIDs},
+	}
+	for _, check := range checks {
+		var count int64
+		err := s.tx.Unscoped().Model(check.model).Where(check.column+" IN ?", check.ids).Count(&count).Error
+		if assert.NoError(s.T(), err) {
+			assert.Zero(s.T(), count, "%T rows left by %s", check.model, check.column)
+		}
+	}
+}
+
+func (s *RetentionTestSuite) TestPurgeService() {
+	f := s.seed("Purge Service")
+
+	result, err := PurgeService(s.tx, f.service.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), PurgeResult{Services: 1, Versions: 1}, result)
+	s.assertPurged([]uint{f.service.ID}, []uint{f.version.ID})
+
+	// The neighbour keeps its version, without the requirement on the target
+	var versions int64
+	s.tx.Model(&models.Version{}).Where("service_id = ?", f.neighbour.ID).Count(&versions)
+	assert.Equal(s.T(), int64(1), versions)
+}
+
+func (s *RetentionTestSuite) TestPurgeDeletedBefore() {
+	f := s.seed("Purge Expired")
+	cutoff := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	expired := cutoff.Add(-time.Hour)
+
+	// A version deleted on its own goes as well, one deleted after the
+	// cutoff stays
+	deleted := models.Version{ServiceID: f.neighbour.ID, Number: "0.9.0"}
+	recent := models.Version{ServiceID: f.neighbour.ID, Number: "0.8.0"}
+	s.create(&deleted, &recent)
+	s.create(
+		&models.VersionSpec{VersionID: deleted.ID, OpenAPI: "3.0.3", Format: "json", Document: `{"openapi": "3.0.3"}`},
+		&models.VersionSBOM{VersionID: deleted.ID, Format: "spdx", SpecVersion: "2.3"},
+	)
+	s.tx.Model(&models.Service{}).Where("id = ?", f.service.ID).Update("deleted_at", expired)
+	s.tx.Model(&models.Version{}).Where("id = ?", deleted.ID).Update("deleted_at", expired)
+	s.tx.Model(&models.Version{}).Where("id = ?", recent.ID).Update("deleted_at", cutoff.Add(time.Hour))
+
+	result, err := PurgeDeletedBefore(s.tx, cutoff)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), PurgeResult{Services: 1, Versions: 2}, result)
+	s.assertPurged([]uint{f.service.ID}, []uint{f.version.ID, deleted.ID})
+
+	var remaining int64
+	s.tx.Unscoped().Model(&models.Version{}).Where("service_id = ?", f.neighbour.ID).Count(&remaining)
+	assert.Equal(s.T(), int64(2), remaining)
+}
+
+func TestRetentionSuite(t *testing.T) {
+	suite.Run(t, new(RetentionTestSuite))
+}
@@ -0,0 +1,172 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidPURL is returned for strings that are not package URLs.
+var ErrInvalidPURL = errors.New("invalid package URL")
+
+// PURL is a package URL, pkg:type/namespace/name@version?qualifiers#subpath,
+// as specified at https://github.com/package-url/purl-spec. Components are
+// held decoded.
+type PURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+// ParsePURL reads a package URL and normalizes it: the type and qualifier
+// keys are lowercased, and so are the namespace and name of types whose
+// registries ignore case. PyPI names also have underscores replaced by
+// dashes.
+func ParsePURL(value string) (PURL, error) {
+	var p PURL
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok || !strings.EqualFold(scheme, "pkg") {
+		return p, fmt.Errorf("%w: %q does not start with pkg:", ErrInvalidPURL, value)
+	}
+	rest = strings.TrimLeft(rest, "/")
+
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		var segments []string
+		for _, segment := range strings.Split(rest[i+1:], "/") {
+			if segment = unescape(segment); segment != "" && segment != "." && segment != ".." {
+				segments = append(segments, segment)
+			}
+		}
+		p.Subpath = strings.Join(segments, "/")
+		rest = rest[:i]
+	}
+
+	if i := strings.LastIndex(rest, "?"); i >= 0 {
+		for _, pair := range strings.Split(rest[i+1:], "&") {
+			key, val, _ := strings.Cut(pair, "=")
+			if key == "" || val == "" {
+				continue
+			}
+			if p.Qualifiers == nil {
+				p.Qualifiers = map[string]string{}
+			}
+			p.Qualifiers[strings.ToLower(key)] = unescape(val)
+		}
+		rest = rest[:i]
+	}
+
+	// The version follows the last "@" of the name, so an npm scope written
+	// unencoded, as in pkg:npm/@angular/core, is not taken for a version
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		p.Version = unescape(rest[i+1:])
+		rest = rest[:i]
+	}
+
+	typ, path, ok := strings.Cut(rest, "/")
+	p.Type = strings.ToLower(typ)
+	if !ok || !validType(p.Type) {
+		return PURL{}, fmt.Errorf("%w: %q has no valid type", ErrInvalidPURL, value)
+	}
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment = unescape(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return PURL{}, fmt.Errorf("%w: %q has no name", ErrInvalidPURL, value)
+	}
+	p.Name = segments[len(segments)-1]
+	p.Namespace = strings.Join(segments[:len(segments)-1], "/")
+
+	switch p.Type {
+	case "bitbucket", "github", "composer":
+		p.Namespace = strings.ToLower(p.Namespace)
+		p.Name = strings.ToLower(p.Name)
+	case "pypi":
+		p.Name = strings.ReplaceAll(strings.ToLower(p.Name), "_", "-")
+	}
+	return p, nil
+}
+
+// String returns the canonical form of the package URL, with qualifiers
+// sorted by key.
+func (p PURL) String() string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(p.Type)
+	b.WriteString("/")
+	if p.Namespace != "" {
+		for _, segment := range strings.Split(p.Namespace, "/") {
+			b.WriteString(escape(segment))
+			b.WriteString("/")
+		}
+	}
+	b.WriteString(escape(p.Name))
+	if p.Version != "" {
+		b.WriteString("@")
+		b.WriteString(escape(p.Version))
+	}
+	if len(p.Qualifiers) > 0 {
+		keys := make([]string, 0, len(p.Qualifiers))
+		for key := range p.Qualifiers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			if i == 0 {
+				b.WriteString("?")
+			} else {
+				b.WriteString("&")
+			}
+			b.WriteString(key)
+			b.WriteString("=")
+			b.WriteString(escape(p.Qualifiers[key]))
+		}
+	}
+	if p.Subpath != "" {
+		for i, segment := range strings.Split(p.Subpath, "/") {
+			if i == 0 {
+				b.WriteString("#")
+			} else {
+				b.WriteString("/")
+			}
+			b.WriteString(escape(segment))
+		}
+	}
+	return b.String()
+}
+
+// validType reports whether typ is made of the characters a package URL
+// type allows and does not start with a digit.
+func validType(typ string) bool {
+	if typ == "" || (typ[0] >= '0' && typ[0] <= '9') {
+		return false
+	}
+	for _, r := range typ {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '+' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// unescape percent-decodes a component, keeping it as written when it is
+// not validly encoded.
+func unescape(value string) string {
+	if decoded, err := url.PathUnescape(value); err == nil {
+		return decoded
+	}
+	return value
+}
+
+// escape percent-encodes a component, including "@" so it cannot be read
+// as the start of a version.
+func escape(value string) string {
+	return strings.ReplaceAll(url.PathEscape(value), "@", "%40")
+}
@@ -0,0 +1,95 @@
+package sbom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePURL(t *testing.T) {
+	tests := []struct {
+		value string
+		want  PURL
+		str   string
+	}{
+		{
+			value: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+			want:  PURL{Type: "maven", Namespace: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1"},
+			str:   "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+		},
+		{
+			value: "pkg:maven/org.apache.logging.log4j/log4j-core",
+			want:  PURL{Type: "maven", Namespace: "org.apache.logging.log4j", Name: "log4j-core"},
+			str:   "pkg:maven/org.apache.logging.log4j/log4j-core",
+		},
+		{
+			value: "pkg:npm/%40angular/animation@12.3.1",
+			want:  PURL{Type: "npm", Namespace: "@angular", Name: "animation", Version: "12.3.1"},
+			str:   "pkg:npm/%40angular/animation@12.3.1",
+		},
+		{
+			value: "pkg:npm/@angular/core",
+			want:  PURL{Type: "npm", Namespace: "@angular", Name: "core"},
+			str:   "pkg:npm/%40angular/core",
+		},
+		{
+			value: "PKG:PyPI/Django_Rest@3.0?os=linux&Arch=amd64#src/lib",
+			want: PURL{
+				Type:       "pypi",
+				Name:       "django-rest",
+				Version:    "3.0",
+				Qualifiers: map[string]string{"arch": "amd64", "os": "linux"},
+				Subpath:    "src/lib",
+			},
+			str: "pkg:pypi/django-rest@3.0?arch=amd64&os=linux#src/lib",
+		},
+		{
+			value: "pkg:GitHub/Package-URL/Purl-Spec@244fd47",
+			want:  PURL{Type: "github", Namespace: "package-url", Name: "purl-spec", Version: "244fd47"},
+			str:   "pkg:github/package-url/purl-spec@244fd47",
+		},
+		{
+			value: "pkg:golang/github.com/gin-gonic/gin@v1.9.1",
+			want:  PURL{Type: "golang", Namespace: "github.com/gin-gonic", Name: "gin", Version: "v1.9.1"},
+			str:   "pkg:golang/github.com/gin-gonic/gin@v1.9.1",
+		},
+		{
+			value: "pkg://deb/debian/curl@7.50.3-1?distro=jessie",
+			want: PURL{
+				Type:       "deb",
+				Namespace:  "debian",
+				Name:       "curl",
+				Version:    "7.50.3-1",
+				Qualifiers: map[string]string{"distro": "jessie"},
+			},
+			str: "pkg:deb/debian/curl@7.50.3-1?distro=jessie",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePURL(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.str, got.String())
+		})
+	}
+}
+
+func TestParsePURLInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"maven/org.apache/log4j",
+		"http://example.com/log4j",
+		"pkg:maven",
+		"pkg:maven/",
+		"pkg:1maven/log4j",
+		"pkg:ma_ven/log4j",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParsePURL(value)
+			assert.True(t, errors.Is(err, ErrInvalidPURL), "got %v", err)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package sbom reads software bills of materials written as CycloneDX JSON
+// or SPDX JSON and normalizes the components they list, so components of
+// both formats can be stored and searched alike.
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Format is the standard a document follows.
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// ErrInvalidDocument is returned for documents that are not CycloneDX or
+// SPDX JSON.
+var ErrInvalidDocument = errors.New("invalid SBOM")
+
+// Component is a package listed in a document.
+type Component struct {
+	// PURL is the canonical package URL, empty when the document gives
+	// none or one that cannot be parsed.
+	PURL    string
+	Name    string
+	Version string
+	// License is an SPDX license expression, empty when unknown.
+	License string
+}
+
+// Document is a parsed bill of materials.
+type Document struct {
+	Format Format
+	// SpecVersion is the version of the standard, e.g. "1.5" or "2.3".
+	SpecVersion string
+	// Components lists each distinct component once, in document order.
+	Components []Component
+}
+
+// Parse reads a CycloneDX or SPDX JSON document, telling them apart by
+// their bomFormat and spdxVersion fields. CycloneDX components nested in
+// others are included; the subject of the document, CycloneDX
+// metadata.component or the packages an SPDX document describes, is not.
+func Parse(data []byte) (*Document, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, fmt.Errorf("%w: not a JSON object", ErrInvalidDocument)
+	}
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+
+	var doc *Document
+	var err error
+	switch {
+	case header.BOMFormat == "CycloneDX":
+		doc, err = parseCycloneDX(data)
+	case strings.HasPrefix(header.SPDXVersion, "SPDX-"):
+		doc, err = parseSPDX(data)
+	default:
+		return nil, fmt.Errorf("%w: neither a CycloneDX nor an SPDX JSON document", ErrInvalidDocument)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	return doc, nil
+}
+
+type cycloneDXComponent struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License *struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var bom struct {
+		SpecVersion string               `json:"specVersion"`
+		Components  []cycloneDXComponent `json:"components"`
+	}
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+	if bom.SpecVersion == "" {
+		return nil, errors.New("specVersion is missing")
+	}
+
+	doc := &Document{Format: FormatCycloneDX, SpecVersion: bom.SpecVersion}
+	seen := map[Component]bool{}
+	var walk func(components []cycloneDXComponent)
+	walk = func(components []cycloneDXComponent) {
+		for _, component := range components {
+			var licenses []string
+			for _, license := range component.Licenses {
+				switch {
+				case license.Expression != "":
+					licenses = append(licenses, license.Expression)
+				case license.License != nil && license.License.ID != "":
+					licenses = append(licenses, license.License.ID)
+				case license.License != nil && license.License.Name != "":
+					licenses = append(licenses, license.License.Name)
+				}
+			}
+			doc.add(seen, component.PURL, component.Name, component.Version, joinLicenses(licenses))
+			walk(component.Components)
+		}
+	}
+	walk(bom.Components)
+	return doc, nil
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var spdx struct {
+		SPDXVersion       string   `json:"spdxVersion"`
+		DocumentDescribes []string `json:"documentDescribes"`
+		Packages          []struct {
+			SPDXID           string `json:"SPDXID"`
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			ExternalRefs     []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+		Relationships []struct {
+			SPDXElementID      string `json:"spdxElementId"`
+			RelationshipType   string `json:"relationshipType"`
+			RelatedSPDXElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, err
+	}
+
+	described := map[string]bool{}
+	for _, id := range spdx.DocumentDescribes {
+		described[id] = true
+	}
+	for _, relationship := range spdx.Relationships {
+		if relationship.SPDXElementID == "SPDXRef-DOCUMENT" && relationship.RelationshipType == "DESCRIBES" {
+			described[relationship.RelatedSPDXElement] = true
+		}
+	}
+
+	doc := &Document{Format: FormatSPDX, SpecVersion: strings.TrimPrefix(spdx.SPDXVersion, "SPDX-")}
+	seen := map[Component]bool{}
+	for _, pkg := range spdx.Packages {
+		if described[pkg.SPDXID] {
+			continue
+		}
+		var purl string
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				purl = ref.ReferenceLocator
+				break
+			}
+		}
+		license := spdxLicense(pkg.LicenseConcluded)
+		if license == "" {
+			license = spdxLicense(pkg.LicenseDeclared)
+		}
+		doc.add(seen, purl, pkg.Name, pkg.VersionInfo, license)
+	}
+	return doc, nil
+}
+
+// add appends a component unless it was seen before. The name and version
+// fall back to those of the package URL; components without a name or
+// package URL are skipped.
+func (d *Document) add(seen map[Component]bool, purl, name, version, license string) {
+	component := Component{
+		Name:    strings.TrimSpace(name),
+		Version: strings.TrimSpace(version),
+		License: strings.TrimSpace(license),
+	}
+	if parsed, err := ParsePURL(purl); err == nil {
+		component.PURL = parsed.String()
+		if component.Name == "" {
+			component.Name = parsed.Name
+		}
+		if component.Version == "" {
+			component.Version = parsed.Version
+		}
+	}
+	if component.Name == "" || seen[component] {
+		return
+	}
+	seen[component] = true
+	d.Components = append(d.Components, component)
+}
+
+// joinLicenses combines the licenses of a CycloneDX component into one
+// expression that requires all of them.
+func joinLicenses(licenses []string) string {
+	if len(licenses) < 2 {
+		return strings.Join(licenses, "")
+	}
+	for i, license := range licenses {
+		if strings.Contains(license, " ") {
+			licenses[i] = "(" + license + ")"
+		}
+	}
+	return strings.Join(licenses, " AND ")
+}
+
+// spdxLicense returns an SPDX license field, or "" for NOASSERTION and
+// NONE.
+func spdxLicense(value string) string {
+	if value == "NOASSERTION" || value == "NONE" {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+package sbom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "component": {"type": "application", "name": "payments", "purl": "pkg:docker/acme/payments@2.0.0"}
+  },
+  "components": [
+    {
+      "type": "library",
+      "group": "org.apache.logging.log4j",
+      "name": "log4j-core",
+      "version": "2.14.1",
+      "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+      "licenses": [{"license": {"id": "Apache-2.0"}}],
+      "components": [
+        {"name": "log4j-api", "purl": "pkg:maven/org.apache.logging.log4j/log4j-api@2.14.1"}
+      ]
+    },
+    {
+      "name": "jackson-databind",
+      "version": "2.15.0",
+      "purl": "pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.0",
+      "licenses": [{"expression": "Apache-2.0 OR MIT"}, {"license": {"name": "Custom"}}]
+    },
+    {
+      "name": "log4j-core",
+      "version": "2.14.1",
+      "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+      "licenses": [{"license": {"id": "Apache-2.0"}}]
+    },
+    {"name": "vendored-lib", "version": "0.1", "purl": "not a purl"},
+    {"version": "1.0"}
+  ]
+}`
+
+const spdx = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "payments",
+  "documentDescribes": ["SPDXRef-payments"],
+  "packages": [
+    {"SPDXID": "SPDXRef-payments", "name": "payments", "versionInfo": "2.0.0"},
+    {
+      "SPDXID": "SPDXRef-log4j",
+      "name": "log4j-core",
+      "versionInfo": "2.14.1",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "Apache-2.0",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:apache:log4j:2.14.1"},
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-gin",
+      "name": "github.com/gin-gonic/gin",
+      "versionInfo": "v1.9.1",
+      "licenseConcluded": "MIT",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/gin-gonic/gin@v1.9.1"}
+      ]
+    },
+    {"SPDXID": "SPDXRef-base", "name": "base-image", "licenseConcluded": "NONE"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-base"},
+    {"spdxElementId": "SPDXRef-payments", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-log4j"}
+  ]
+}`
+
+func TestParseCycloneDX(t *testing.T) {
+	doc, err := Parse([]byte(cycloneDX))
+	require.NoError(t, err)
+	assert.Equal(t, FormatCycloneDX, doc.Format)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Equal(t, []Component{
+		{
+			PURL:    "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+			Name:    "log4j-core",
+			Version: "2.14.1",
+			License: "Apache-2.0",
+		},
+		{
+			PURL:    "pkg:maven/org.apache.logging.log4j/log4j-api@2.14.1",
+			Name:    "log4j-api",
+			Version: "2.14.1",
+		},
+		{
+			PURL:    "pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.0",
+			Name:    "jackson-databind",
+			Version: "2.15.0",
+			License: "(Apache-2.0 OR MIT) AND Custom",
+		},
+		{Name: "vendored-lib", Version: "0.1"},
+	}, doc.Components)
+}
+
+func TestParseSPDX(t *testing.T) {
+	doc, err := Parse([]byte(spdx))
+	require.NoError(t, err)
+	assert.Equal(t, FormatSPDX, doc.Format)
+	assert.Equal(t, "2.3", doc.SpecVersion)
+	assert.Equal(t, []Component{
+		{
+			PURL:    "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+			Name:    "log4j-core",
+			Version: "2.14.1",
+			License: "Apache-2.0",
+		},
+		{
+			PURL:    "pkg:golang/github.com/gin-gonic/gin@v1.9.1",
+			Name:    "github.com/gin-gonic/gin",
+			Version: "v1.9.1",
+			License: "MIT",
+		},
+	}, doc.Components)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":            "",
+		"not an object":    `["CycloneDX"]`,
+		"malformed":        `{"bomFormat": "CycloneDX",`,
+		"unknown format":   `{"openapi": "3.0.3"}`,
+		"no spec version":  `{"bomFormat": "CycloneDX", "components": []}`,
+		"wrong field type": `{"spdxVersion": "SPDX-2.3", "packages": {}}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.True(t, errors.Is(err, ErrInvalidDocument), "got %v", err)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidConstraint = errors.New("invalid version range")
+
+// Operator is a comparison operator of a range comparator.
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+)
+
+// Comparator compares a version against a release version bound.
+//
+// Pre-releases only satisfy a comparator when their MAJOR.MINOR.PATCH is
+// strictly inside the bound, so "<2.0.0" does not match "2.0.0-rc.1" and
+// ">=2.0.0" does not match it either.
+type Comparator struct {
+	Op      Operator
+	Version Version
+}
+
+// Constraint is a version range: a union of comparator sets, where every
+// comparator of a set must match.
+type Constraint struct {
+	raw  string
+	sets [][]Comparator
+}
+
+// ParseConstraint parses a version range such as "^1.2", "~1.4.0",
+// "1.x", ">=2.0.0 <3.0.0" or "^1.0.0 || ^2.0.0". Comparators separated by
+// whitespace must all match, alternatives are separated by "||". Partial
+// versions are allowed, but bounds may not carry pre-release identifiers.
+func ParseConstraint(s string) (*Constraint, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("%w: empty range", ErrInvalidConstraint)
+	}
+
+	c := &Constraint{raw: s}
+	for _, alternative := range strings.Split(s, "||") {
+		fields := strings.Fields(normalizeOperators(alternative))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w: empty alternative in %q", ErrInvalidConstraint, s)
+		}
+
+		var set []Comparator
+		for _, field := range fields {
+			comparators, err := parseComparator(field)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, comparators...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// String returns the range as it was parsed.
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Sets returns the comparator sets of the range. A version satisfies the
+// range when it matches every comparator of at least one set. A set without
+// comparators matches every version.
+func (c *Constraint) Sets() [][]Comparator {
+	return c.sets
+}
+
+// Check reports whether v satisfies the range.
+func (c *Constraint) Check(v Version) bool {
+	for _, set := range c.sets {
+		matched := true
+		for _, comparator := range set {
+			if !comparator.Check(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Check reports whether v matches the comparator.
+func (cmp Comparator) Check(v Version) bool {
+	core := compareCore(v, cmp.Version)
+	switch cmp.Op {
+	case OpEqual:
+		return core == 0 && !v.IsPreRelease()
+	case OpGreater:
+		return core > 0
+	case OpGreaterEqual:
+		return core > 0 || (core == 0 && !v.IsPreRelease())
+	case OpLess:
+		return core < 0
+	case OpLessEqual:
+		return core <= 0
+	}
+	return false
+}
+
+// compareCore compares MAJOR.MINOR.PATCH only.
+func compareCore(a, b Version) int {
+	return Version{Major: a.Major, Minor: a.Minor, Patch: a.Patch}.
+		Compare(Version{Major: b.Major, Minor: b.Minor, Patch: b.Patch})
+}
+
+// normalizeOperators removes whitespace between an operator and its
+// version, so ">= 2.0.0" is read like ">=2.0.0".
+func normalizeOperators(s string) string {
+	for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		for strings.Contains(s, op+" ") {
+			s = strings.ReplaceAll(s, op+" ", op)
+		}
+	}
+	return s
+}
+
+// parseComparator expands a single range term into primitive comparators.
+func parseComparator(term string) ([]Comparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+
+	p, err := parsePartial(strings.TrimPrefix(term, op))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidConstraint, term, err)
+	}
+
+	lower := p.floor()
+	switch op {
+	case "^":
+		return caretRange(p), nil
+	case "~":
+		if p.parts == 0 {
+			return nil, nil
+		}
+		if p.parts == 1 {
+			return between(lower, Version{Major: p.major + 1}), nil
+		}
+		return between(lower, Version{Major: p.major, Minor: p.minor + 1}), nil
+	case ">=":
+		if p.parts == 0 {
+			return nil, nil
+		}
+		return []Comparator{{Op: OpGreaterEqual, Version: lower}}, nil
+	case ">":
+		if p.parts == 0 {
+			return []Comparator{{Op: OpLess, Version: Version{}}}, nil // matches nothing
+		}
+		if p.parts < 3 {
+			return []Comparator{{Op: OpGreaterEqual, Version: p.ceiling()}}, nil
+		}
+		return []Comparator{{Op: OpGreater, Version: lower}}, nil
+	case "<":
+		if p.parts == 0 {
+			return []Comparator{{Op: OpLess, Version: Version{}}}, nil // matches nothing
+		}
+		return []Comparator{{Op: OpLess, Version: lower}}, nil
+	case "<=":
+		if p.parts == 0 {
+			return nil, nil
+		}
+		if p.parts < 3 {
+			return []Comparator{{Op: OpLess, Version: p.ceiling()}}, nil
+		}
+		return []Comparator{{Op: OpLessEqual, Version: lower}}, nil
+	default: // "=" or no operator
+		if p.parts == 0 {
+			return nil, nil
+		}
+		if p.parts < 3 {
+			return between(lower, p.ceiling()), nil
+		}
+		return []Comparator{{Op: OpEqual, Version: lower}}, nil
+	}
+}
+
+// caretRange allows changes that do not modify the left-most non-zero
+// component: ^1.2.3 is >=1.2.3 <2.0.0, ^0.2.3 is >=0.2.3 <0.3.0.
+func caretRange(p partial) []Comparator {
+	lower := p.floor()
+	switch {
+	case p.parts == 0:
+		return nil
+	case p.major > 0 || p.parts == 1:
+		return between(lower, Version{Major: p.major + 1})
+	case p.minor > 0 || p.parts == 2:
+		return between(lower, Version{Major: p.major, Minor: p.minor + 1})
+	default:
+		return between(lower, Version{Major: p.major, Minor: p.minor, Patch: p.patch + 1})
+	}
+}
+
+func between(lower, upper Version) []Comparator {
+	return []Comparator{
+		{Op: OpGreaterEqual, Version: lower},
+		{Op: OpLess, Version: upper},
+	}
+}
+
+// partial is a version with possibly missing or wildcard components,
+// such as "1", "1.2", "1.2.x" or "*".
+type partial struct {
+	major, minor, patch uint64
+	parts               int // number of leading components that are set
+}
+
+func parsePartial(s string) (partial, error) {
+	if s == "" {
+		return partial{}, ErrInvalidFormat
+	}
+	if strings.ContainsAny(s, "-+") {
+		return partial{}, errors.New("range bounds cannot carry pre-release or build identifiers")
+	}
+
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return partial{}, ErrInvalidFormat
+	}
+
+	var p partial
+	components := []*uint64{&p.major, &p.minor, &p.patch}
+	wildcard := false
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return partial{}, errors.New("a component cannot follow a wildcard")
+		}
+		n, err := parseNumeric(field)
+		if err != nil {
+			return partial{}, err
+		}
+		*components[i] = n
+		p.parts = i + 1
+	}
+	return p, nil
+}
+
+// floor returns the lowest version matched by the partial version.
+func (p partial) floor() Version {
+	return Version{Major: p.major, Minor: p.minor, Patch: p.patch}
+}
+
+// ceiling returns the lowest version above every version matched by the
+// partial version, e.g. 1.3.0 for "1.2".
+func (p partial) ceiling() Version {
+	switch p.parts {
+	case 1:
+		return Version{Major: p.major + 1}
+	case 2:
+		return Version{Major: p.major, Minor: p.minor + 1}
+	}
+	return Version{Major: p.major, Minor: p.minor, Patch: p.patch + 1}
+}
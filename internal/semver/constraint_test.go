@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{
+			constraint: "^1.2",
+			matches:    []string{"1.2.0", "1.9.9", "1.10.0", "1.3.0-beta.1"},
+			rejects:    []string{"1.1.9", "2.0.0", "2.0.0-rc.1", "1.2.0-rc.1"},
+		},
+		{
+			constraint: "^0.2.3",
+			matches:    []string{"0.2.3", "0.2.9"},
+			rejects:    []string{"0.3.0", "0.2.2"},
+		},
+		{
+			constraint: "~1.4.0",
+			matches:    []string{"1.4.0", "1.4.7"},
+			rejects:    []string{"1.5.0", "1.3.9"},
+		},
+		{
+			constraint: ">=2.0.0 <3.0.0",
+			matches:    []string{"2.0.0", "2.10.1"},
+			rejects:    []string{"1.9.9", "3.0.0", "2.0.0-rc.1", "3.0.0-rc.1"},
+		},
+		{
+			constraint: ">= 2.0.0 < 3.0.0",
+			matches:    []string{"2.5.0"},
+			rejects:    []string{"3.0.0"},
+		},
+		{
+			constraint: "1.x",
+			matches:    []string{"1.0.0", "1.99.0"},
+			rejects:    []string{"2.0.0", "0.9.0"},
+		},
+		{
+			constraint: ">1",
+			matches:    []string{"2.0.0"},
+			rejects:    []string{"1.9.0"},
+		},
+		{
+			constraint: "<=1.2",
+			matches:    []string{"1.2.9"},
+			rejects:    []string{"1.3.0"},
+		},
+		{
+			constraint: "=1.0.0",
+			matches:    []string{"1.0.0", "1.0.0+build.1"},
+			rejects:    []string{"1.0.1", "1.0.0-rc.1"},
+		},
+		{
+			constraint: "^1.0.0 || ^3.0.0",
+			matches:    []string{"1.5.0", "3.1.0"},
+			rejects:    []string{"2.0.0"},
+		},
+		{
+			constraint: "*",
+			matches:    []string{"0.0.1", "9.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, number := range tt.matches {
+				v, err := Parse(number)
+				assert.NoError(t, err)
+				assert.True(t, c.Check(v), "%s should match %s", tt.constraint, number)
+			}
+			for _, number := range tt.rejects {
+				v, err := Parse(number)
+				assert.NoError(t, err)
+				assert.False(t, c.Check(v), "%s should not match %s", tt.constraint, number)
+			}
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, constraint := range []string{"", "abc", ">=1.0.0-rc.1", "1.x.3", "^1.2.3.4", "^1.0.0 ||"} {
+		_, err := ParseConstraint(constraint)
+		assert.ErrorIs(t, err, ErrInvalidConstraint, constraint)
+	}
+}
+
+func TestPreReleaseKeyOrdering(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha-x",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, _ := Parse(ordered[i])
+		higher, _ := Parse(ordered[i+1])
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i], ordered[i+1])
+		assert.Less(t, lower.PreReleaseKey(), higher.PreReleaseKey(), "%s < %s", ordered[i], ordered[i+1])
+	}
+}
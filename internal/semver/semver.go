@@ -0,0 +1,208 @@
+// Package semver parses and compares version numbers following
+// Semantic Versioning 2.0.0 (https://semver.org).
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrEmpty                = errors.New("version number is empty")
+	ErrInvalidFormat        = errors.New("version number must have the form MAJOR.MINOR.PATCH")
+	ErrInvalidNumber        = errors.New("version components must be non-negative integers without leading zeros")
+	ErrInvalidPreRelease    = errors.New("invalid pre-release identifier")
+	ErrInvalidBuildMetadata = errors.New("invalid build metadata identifier")
+)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string
+	Build      string
+}
+
+// Parse parses a strict semantic version such as "1.2.3", "2.0.0-rc.1"
+// or "1.0.0+build.5". Prefixes like "v" and partial versions are rejected.
+func Parse(number string) (Version, error) {
+	if number == "" {
+		return Version{}, ErrEmpty
+	}
+
+	var v Version
+	rest := number
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+		if err := checkIdentifiers(v.Build, false); err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidBuildMetadata, v.Build)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.PreRelease = rest[i+1:]
+		rest = rest[:i]
+		if err := checkIdentifiers(v.PreRelease, true); err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidPreRelease, v.PreRelease)
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidFormat, number)
+	}
+
+	components := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := parseNumeric(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidNumber, number)
+		}
+		*components[i] = n
+	}
+
+	return v, nil
+}
+
+// String returns the canonical representation of the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsPreRelease reports whether the version carries a pre-release identifier.
+func (v Version) IsPreRelease() bool {
+	return v.PreRelease != ""
+}
+
+// Compare returns -1, 0 or 1 when v has lower, equal or higher precedence
+// than other. Build metadata does not affect precedence.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// comparePreRelease compares pre-release strings. A version without a
+// pre-release has higher precedence than one with a pre-release.
+func comparePreRelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aIDs := strings.Split(a, ".")
+	bIDs := strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		aNum, aErr := strconv.ParseUint(aIDs[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(bIDs[i], 10, 64)
+
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareUint(aNum, bNum)
+		case aErr == nil:
+			c = -1 // numeric identifiers sort before alphanumeric ones
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(aIDs[i], bIDs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(aIDs)), uint64(len(bIDs)))
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseNumeric parses a numeric component, rejecting leading zeros.
+func parseNumeric(s string) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, ErrInvalidNumber
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidNumber
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// checkIdentifiers validates dot-separated identifiers made of
+// [0-9A-Za-z-]. Numeric pre-release identifiers must not have leading zeros.
+func checkIdentifiers(s string, preRelease bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return ErrInvalidFormat
+		}
+		numeric := true
+		for _, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return ErrInvalidFormat
+			}
+		}
+		if preRelease && numeric && len(id) > 1 && id[0] == '0' {
+			return ErrInvalidNumber
+		}
+	}
+	return nil
+}
+
+// PreReleaseKey returns a string whose byte-wise ordering matches the
+// precedence of the pre-release part, so versions can be ordered in SQL
+// with (major, minor, patch, pre_release_key COLLATE "C").
+//
+// Numeric identifiers are zero-padded so they compare numerically and sort
+// before alphanumeric ones, identifiers are joined by a space which sorts
+// below every identifier character, and releases map to "~" which sorts
+// after any pre-release.
+func (v Version) PreReleaseKey() string {
+	if v.PreRelease == "" {
+		return "~"
+	}
+
+	ids := strings.Split(v.PreRelease, ".")
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+			keys[i] = fmt.Sprintf("0%020d", n)
+		} else {
+			keys[i] = "1" + id
+		}
+	}
+	return strings.Join(keys, " ")
+}
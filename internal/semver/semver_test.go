@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      Version
+		expectedError bool
+	}{
+		{name: "Release", input: "1.2.3", expected: Version{Major: 1, Minor: 2, Patch: 3}},
+		{name: "Pre-release", input: "2.0.0-rc.1", expected: Version{Major: 2, PreRelease: "rc.1"}},
+		{name: "Build metadata", input: "1.0.0+build.5", expected: Version{Major: 1, Build: "build.5"}},
+		{name: "Pre-release and build", input: "1.0.0-alpha-1+001", expected: Version{Major: 1, PreRelease: "alpha-1", Build: "001"}},
+		{name: "Empty", input: "", expectedError: true},
+		{name: "Partial", input: "1.2", expectedError: true},
+		{name: "Prefix", input: "v1.2.3", expectedError: true},
+		{name: "Leading zero", input: "01.2.3", expectedError: true},
+		{name: "Leading zero pre-release", input: "1.2.3-01", expectedError: true},
+		{name: "Empty pre-release identifier", input: "1.2.3-rc..1", expectedError: true},
+		{name: "Invalid character", input: "1.2.3-rc_1", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Parse(tt.input)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+			assert.Equal(t, tt.input, v.String())
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Ordered by increasing precedence, taken from the SemVer specification.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.9.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, _ := Parse(ordered[i])
+		higher, _ := Parse(ordered[i+1])
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	a, _ := Parse("1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	assert.Equal(t, 0, a.Compare(b))
+}
@@ -0,0 +1,51 @@
+// Package slug generates and checks URL-safe service identifiers such as
+// "payment-gateway".
+package slug
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxLength is the maximum length of a slug.
+const MaxLength = 100
+
+var (
+	pattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	hasLetter = regexp.MustCompile(`[a-z]`)
+)
+
+// Make derives a slug from a name: ASCII letters and digits are kept and
+// lowercased, every other run of characters becomes a single hyphen.
+// Names without letters are prefixed with "service-" so that the slug can
+// never be mistaken for a numeric ID.
+func Make(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingHyphen = false
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	s := b.String()
+	if !hasLetter.MatchString(s) {
+		s = strings.TrimSuffix("service-"+s, "-")
+	}
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	return s
+}
+
+// Valid reports whether s is a well-formed slug: lowercase letters, digits
+// and single inner hyphens, at least one letter, at most MaxLength bytes.
+func Valid(s string) bool {
+	return len(s) <= MaxLength && pattern.MatchString(s) && hasLetter.MatchString(s)
+}
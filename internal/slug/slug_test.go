@@ -0,0 +1,41 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Payment Gateway", expected: "payment-gateway"},
+		{name: "  Auth -- Service v2 ", expected: "auth-service-v2"},
+		{name: "Café Menu", expected: "caf-menu"},
+		{name: "2024", expected: "service-2024"},
+		{name: "!!!", expected: "service"},
+		{name: strings.Repeat("a", 150), expected: strings.Repeat("a", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Make(tt.name)
+			assert.Equal(t, tt.expected, s)
+			assert.True(t, Valid(s))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("payment-gateway"))
+	assert.True(t, Valid("auth2"))
+	assert.False(t, Valid("42"))
+	assert.False(t, Valid("Payment"))
+	assert.False(t, Valid("-payment"))
+	assert.False(t, Valid("payment--gateway"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid(strings.Repeat("a", 101)))
+}
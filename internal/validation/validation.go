@@ -1,22 +1,478 @@
 package validation
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"net/mail"
+	"net/url"
 	"serviceCatalog/internal/constants"
+	"serviceCatalog/internal/models"
+	"serviceCatalog/internal/semver"
+	"serviceCatalog/internal/slug"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type ServiceIDParam struct {
-	ID uint64 `uri:"id" binding:"required,min=1"`
+	ID string `uri:"id" binding:"required"`
+}
+
+// ServiceRef identifies a service either by its numeric ID or by its slug.
+// Exactly one of the fields is set.
+type ServiceRef struct {
+	ID   uint64
+	Slug string
 }
 
-func ValidateServiceID(c *gin.Context) (uint64, *constants.ServiceError) {
+// ValidateServiceID reads the :id path parameter, which may be a positive
+// integer service ID or a service slug such as "payment-gateway".
+func ValidateServiceID(c *gin.Context) (ServiceRef, *constants.ServiceError) {
 	var param ServiceIDParam
 	if err := c.ShouldBindUri(&param); err != nil {
-		return 0, &constants.ServiceError{
+		return ServiceRef{}, &constants.ServiceError{
 			Status:  constants.StatusBadRequest,
 			Message: constants.ErrInvalidServiceID,
 			Details: err.Error(),
 		}
 	}
+
+	if id, err := strconv.ParseUint(param.ID, 10, 64); err == nil && id > 0 {
+		return ServiceRef{ID: id}, nil
+	}
+	if slug.Valid(param.ID) {
+		return ServiceRef{Slug: param.ID}, nil
+	}
+	return ServiceRef{}, &constants.ServiceError{
+		Status:  constants.StatusBadRequest,
+		Message: constants.ErrInvalidServiceID,
+		Details: param.ID,
+	}
+}
+
+type VersionParam struct {
+	Version string `uri:"version" binding:"required"`
+}
+
+// VersionRef identifies a version of a service either by its numeric ID
+// or by its semantic version number. Exactly one of the fields is set.
+type VersionRef struct {
+	ID     uint64
+	Number string
+}
+
+// ValidateVersionRef reads the :version path parameter, which may be a
+// positive integer version ID or a semantic version number such as "2.1.0".
+func ValidateVersionRef(c *gin.Context) (VersionRef, *constants.ServiceError) {
+	var param VersionParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		return VersionRef{}, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidVersionRef,
+			Details: err.Error(),
+		}
+	}
+	return ParseVersionRef(param.Version)
+}
+
+// ParseVersionRef reads a version reference given as a positive integer
+// version ID or a semantic version number.
+func ParseVersionRef(value string) (VersionRef, *constants.ServiceError) {
+	if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+		if id == 0 {
+			return VersionRef{}, &constants.ServiceError{
+				Status:  constants.StatusBadRequest,
+				Message: constants.ErrInvalidVersionRef,
+				Details: value,
+			}
+		}
+		return VersionRef{ID: id}, nil
+	}
+
+	if _, err := semver.Parse(value); err != nil {
+		return VersionRef{}, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidVersionRef,
+			Details: err.Error(),
+		}
+	}
+	return VersionRef{Number: value}, nil
+}
+
+// ValidateServiceName checks that a service name is present and fits the
+// services.name column.
+func ValidateServiceName(name string) *constants.FieldError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return &constants.FieldError{Field: constants.Name, Message: constants.ErrFieldRequired}
+	}
+	if utf8.RuneCountInString(name) > constants.MaxServiceNameLength {
+		return &constants.FieldError{
+			Field:   constants.Name,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxServiceNameLength),
+		}
+	}
+	return nil
+}
+
+// ValidateServiceDescription checks that a service description is within
+// the allowed length. An empty description is accepted.
+func ValidateServiceDescription(description string) *constants.FieldError {
+	if utf8.RuneCountInString(description) > constants.MaxServiceDescriptionLength {
+		return &constants.FieldError{
+			Field:   constants.Description,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxServiceDescriptionLength),
+		}
+	}
+	return nil
+}
+
+// ValidateVersionNumber checks that a version number is a valid
+// semantic version (https://semver.org), e.g. "1.2.3" or "2.0.0-rc.1".
+func ValidateVersionNumber(number string) *constants.FieldError {
+	if number == "" {
+		return &constants.FieldError{Field: constants.Number, Message: constants.ErrFieldRequired}
+	}
+	if _, err := semver.Parse(number); err != nil {
+		return &constants.FieldError{Field: constants.Number, Message: err.Error()}
+	}
+	return nil
+}
+
+// ValidateReleaseNotes checks that the release notes of a version are
+// within the allowed length. Empty notes are accepted.
+func ValidateReleaseNotes(notes string) *constants.FieldError {
+	if utf8.RuneCountInString(notes) > constants.MaxReleaseNotesLength {
+		return &constants.FieldError{
+			Field:   constants.ReleaseNotes,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxReleaseNotesLength),
+		}
+	}
+	return nil
+}
+
+// ValidateVersionStatus checks that status is one of models.VersionStatuses.
+func ValidateVersionStatus(status string) *constants.FieldError {
+	if status == "" {
+		return &constants.FieldError{Field: constants.Status, Message: constants.ErrFieldRequired}
+	}
+	if !models.VersionStatus(status).Valid() {
+		return &constants.FieldError{Field: constants.Status, Message: fmt.Sprintf(constants.ErrFieldNotOneOf, VersionStatusNames())}
+	}
+	return nil
+}
+
+// ValidatePublishStatus checks the status a version is published with,
+// which is either draft or released. An empty status is accepted and
+// published as released.
+func ValidatePublishStatus(status string) *constants.FieldError {
+	switch models.VersionStatus(status) {
+	case "", models.VersionDraft, models.VersionReleased:
+		return nil
+	}
+	return &constants.FieldError{
+		Field:   constants.Status,
+		Message: fmt.Sprintf(constants.ErrFieldNotOneOf, models.VersionDraft+", "+models.VersionReleased),
+	}
+}
+
+// VersionStatusNames returns the version statuses as a comma separated list.
+func VersionStatusNames() string {
+	names := make([]string, len(models.VersionStatuses))
+	for i, status := range models.VersionStatuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValidateEndOfLifeDate checks that a version does not reach its end of
+// life before it is deprecated. Either date may be unset.
+func ValidateEndOfLifeDate(deprecation, endOfLife *time.Time) *constants.FieldError {
+	if deprecation != nil && endOfLife != nil && endOfLife.Before(*deprecation) {
+		return &constants.FieldError{Field: constants.EndOfLifeDate, Message: constants.ErrEndOfLifeTooEarly}
+	}
+	return nil
+}
+
+// ValidateSlug checks that an explicitly provided service slug is
+// well-formed. An empty slug is accepted and generated from the name.
+func ValidateSlug(value string) *constants.FieldError {
+	if value != "" && !slug.Valid(value) {
+		return &constants.FieldError{Field: constants.Slug, Message: constants.ErrInvalidSlug}
+	}
+	return nil
+}
+
+type LinkIDParam struct {
+	LinkID uint64 `uri:"linkId" binding:"required,min=1"`
+}
+
+// ValidateLinkID reads the :linkId path parameter, which must be a
+// positive integer link ID.
+func ValidateLinkID(c *gin.Context) (uint64, *constants.ServiceError) {
+	var param LinkIDParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		return 0, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidLinkID,
+			Details: err.Error(),
+		}
+	}
+	return param.LinkID, nil
+}
+
+type DependencyIDParam struct {
+	DependencyID uint64 `uri:"dependencyId" binding:"required,min=1"`
+}
+
+// ValidateDependencyID reads the :dependencyId path parameter, which must
+// be a positive integer dependency ID.
+func ValidateDependencyID(c *gin.Context) (uint64, *constants.ServiceError) {
+	var param DependencyIDParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		return 0, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidDepID,
+			Details: err.Error(),
+		}
+	}
+	return param.DependencyID, nil
+}
+
+// ValidateDependencyKind checks that kind is one of models.DependencyKinds.
+func ValidateDependencyKind(kind string) *constants.FieldError {
+	if kind == "" {
+		return &constants.FieldError{Field: constants.Kind, Message: constants.ErrFieldRequired}
+	}
+	if !models.DependencyKind(kind).Valid() {
+		return &constants.FieldError{Field: constants.Kind, Message: fmt.Sprintf(constants.ErrFieldNotOneOf, DependencyKindNames())}
+	}
+	return nil
+}
+
+// DependencyKindNames returns the dependency kinds as a comma separated list.
+func DependencyKindNames() string {
+	names := make([]string, len(models.DependencyKinds))
+	for i, kind := range models.DependencyKinds {
+		names[i] = string(kind)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValidateCriticality checks that criticality is one of
+// models.Criticalities. An empty criticality is accepted.
+func ValidateCriticality(criticality string) *constants.FieldError {
+	if criticality != "" && !models.Criticality(criticality).Valid() {
+		return &constants.FieldError{Field: constants.Criticality, Message: fmt.Sprintf(constants.ErrFieldNotOneOf, CriticalityNames())}
+	}
+	return nil
+}
+
+// CriticalityNames returns the criticalities as a comma separated list.
+func CriticalityNames() string {
+	names := make([]string, len(models.Criticalities))
+	for i, criticality := range models.Criticalities {
+		names[i] = string(criticality)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValidateLinkType checks that a link type is one of models.LinkTypes.
+func ValidateLinkType(linkType string) *constants.FieldError {
+	if linkType == "" {
+		return &constants.FieldError{Field: constants.Type, Message: constants.ErrFieldRequired}
+	}
+	if !models.LinkType(linkType).Valid() {
+		return &constants.FieldError{Field: constants.Type, Message: fmt.Sprintf(constants.ErrFieldNotOneOf, LinkTypeNames())}
+	}
+	return nil
+}
+
+// LinkTypeNames returns the accepted link types as a comma separated list.
+func LinkTypeNames() string {
+	names := make([]string, len(models.LinkTypes))
+	for i, linkType := range models.LinkTypes {
+		names[i] = string(linkType)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValidateLinkURL checks that a link URL is an absolute http or https URL
+// with a host, e.g. "https://github.com/example/payments".
+func ValidateLinkURL(value string) *constants.FieldError {
+	if value == "" {
+		return &constants.FieldError{Field: constants.URL, Message: constants.ErrFieldRequired}
+	}
+	if len(value) > constants.MaxLinkURLLength {
+		return &constants.FieldError{
+			Field:   constants.URL,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxLinkURLLength),
+		}
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &constants.FieldError{Field: constants.URL, Message: constants.ErrInvalidURL}
+	}
+	return nil
+}
+
+// ValidateLinkTitle checks that a link title is within the allowed
+// length. An empty title is accepted.
+func ValidateLinkTitle(title string) *constants.FieldError {
+	if utf8.RuneCountInString(title) > constants.MaxLinkTitleLength {
+		return &constants.FieldError{
+			Field:   constants.Title,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxLinkTitleLength),
+		}
+	}
+	return nil
+}
+
+// ValidateLifecycleStage checks that stage is one of models.LifecycleStages,
+// reporting errors for field.
+func ValidateLifecycleStage(field, stage string) *constants.FieldError {
+	if stage == "" {
+		return &constants.FieldError{Field: field, Message: constants.ErrFieldRequired}
+	}
+	if !models.LifecycleStage(stage).Valid() {
+		return &constants.FieldError{Field: field, Message: fmt.Sprintf(constants.ErrFieldNotOneOf, LifecycleStageNames())}
+	}
+	return nil
+}
+
+// ValidateInitialLifecycle checks the stage a service is created in, which
+// is proposed, experimental or production. Deprecated and retired are only
+// reached through transitions, so they are recorded in the history. An
+// empty stage is accepted and created as proposed.
+func ValidateInitialLifecycle(stage string) *constants.FieldError {
+	switch models.LifecycleStage(stage) {
+	case "", models.LifecycleProposed, models.LifecycleExperimental, models.LifecycleProduction:
+		return nil
+	}
+	return &constants.FieldError{
+		Field: constants.Lifecycle,
+		Message: fmt.Sprintf(constants.ErrFieldNotOneOf,
+			models.LifecycleProposed+", "+models.LifecycleExperimental+", "+models.LifecycleProduction),
+	}
+}
+
+// LifecycleStageNames returns the lifecycle stages as a comma separated list.
+func LifecycleStageNames() string {
+	names := make([]string, len(models.LifecycleStages))
+	for i, stage := range models.LifecycleStages {
+		names[i] = string(stage)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValidateActor checks that the person or system making a change is named.
+func ValidateActor(actor string) *constants.FieldError {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return &constants.FieldError{Field: constants.Actor, Message: constants.ErrFieldRequired}
+	}
+	if utf8.RuneCountInString(actor) > constants.MaxActorLength {
+		return &constants.FieldError{
+			Field:   constants.Actor,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxActorLength),
+		}
+	}
+	return nil
+}
+
+// ValidateReason checks that the reason given for a change is within the
+// allowed length. An empty reason is accepted.
+func ValidateReason(reason string) *constants.FieldError {
+	if utf8.RuneCountInString(reason) > constants.MaxReasonLength {
+		return &constants.FieldError{
+			Field:   constants.Reason,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxReasonLength),
+		}
+	}
+	return nil
+}
+
+type TeamIDParam struct {
+	ID uint64 `uri:"id" binding:"required,min=1"`
+}
+
+// ValidateTeamID reads the :id path parameter of a team route, which must
+// be a positive integer team ID.
+func ValidateTeamID(c *gin.Context) (uint64, *constants.ServiceError) {
+	var param TeamIDParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		return 0, &constants.ServiceError{
+			Status:  constants.StatusBadRequest,
+			Message: constants.ErrInvalidTeamID,
+			Details: err.Error(),
+		}
+	}
 	return param.ID, nil
 }
+
+// ValidateTeamName checks that a team name is present and fits the
+// teams.name column.
+func ValidateTeamName(name string) *constants.FieldError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return &constants.FieldError{Field: constants.Name, Message: constants.ErrFieldRequired}
+	}
+	if utf8.RuneCountInString(name) > constants.MaxTeamNameLength {
+		return &constants.FieldError{
+			Field:   constants.Name,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxTeamNameLength),
+		}
+	}
+	return nil
+}
+
+// ValidateContactEmail checks that a team contact is a bare email address
+// such as "payments@example.com". An empty contact is accepted.
+func ValidateContactEmail(email string) *constants.FieldError {
+	if email == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(email) > constants.MaxContactLength {
+		return &constants.FieldError{
+			Field:   constants.ContactEmail,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxContactLength),
+		}
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return &constants.FieldError{Field: constants.ContactEmail, Message: constants.ErrInvalidEmail}
+	}
+	return nil
+}
+
+// ValidateChatChannel checks that a team chat channel is within the
+// allowed length. An empty channel is accepted.
+func ValidateChatChannel(channel string) *constants.FieldError {
+	if utf8.RuneCountInString(channel) > constants.MaxChatChannelLength {
+		return &constants.FieldError{
+			Field:   constants.ChatChannel,
+			Message: fmt.Sprintf(constants.ErrFieldTooLong, constants.MaxChatChannelLength),
+		}
+	}
+	return nil
+}
+
+// NewValidationError collects the non-nil field errors into a single
+// ServiceError. It returns nil when every field is valid.
+func NewValidationError(fieldErrors ...*constants.FieldError) *constants.ServiceError {
+	var fields []constants.FieldError
+	for _, fieldErr := range fieldErrors {
+		if fieldErr != nil {
+			fields = append(fields, *fieldErr)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &constants.ServiceError{
+		Status:  constants.StatusUnprocessableEntity,
+		Message: constants.ErrValidationFailed,
+		Fields:  fields,
+	}
+}
@@ -1,7 +1,9 @@
 package validation
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -11,25 +13,34 @@ func TestValidateServiceID(t *testing.T) {
 	tests := []struct {
 		name          string
 		paramID       string
-		expectedID    uint64
+		expectedRef   ServiceRef
 		expectedError bool
 	}{
 		{
 			name:          "Valid ID",
 			paramID:       "123",
-			expectedID:    123,
+			expectedRef:   ServiceRef{ID: 123},
 			expectedError: false,
 		},
 		{
-			name:          "Invalid ID - String",
-			paramID:       "abc",
-			expectedID:    0,
+			name:          "Valid slug",
+			paramID:       "payment-gateway",
+			expectedRef:   ServiceRef{Slug: "payment-gateway"},
+			expectedError: false,
+		},
+		{
+			name:          "Invalid ID - Uppercase slug",
+			paramID:       "Payment",
 			expectedError: true,
 		},
 		{
 			name:          "Invalid ID - Negative",
 			paramID:       "-1",
-			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name:          "Invalid ID - Zero",
+			paramID:       "0",
 			expectedError: true,
 		},
 	}
@@ -40,14 +51,192 @@ func TestValidateServiceID(t *testing.T) {
 			c, _ := gin.CreateTestContext(nil)
 			c.Params = []gin.Param{{Key: "id", Value: tt.paramID}}
 
-			id, err := ValidateServiceID(c)
+			ref, err := ValidateServiceID(c)
+
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedRef, ref)
+			}
+		})
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	assert.Nil(t, ValidateSlug(""))
+	assert.Nil(t, ValidateSlug("payment-gateway"))
+
+	err := ValidateSlug("Payment Gateway")
+	assert.NotNil(t, err)
+	assert.Equal(t, "slug", err.Field)
+}
+
+func TestValidateServiceName(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError bool
+	}{
+		{name: "Valid name", input: "Billing Service", expectedError: false},
+		{name: "Empty name", input: "", expectedError: true},
+		{name: "Whitespace only", input: "   ", expectedError: true},
+		{name: "Too long", input: strings.Repeat("a", 256), expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServiceName(tt.input)
+			if tt.expectedError {
+				assert.NotNil(t, err)
+				assert.Equal(t, "name", err.Field)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestNewValidationError(t *testing.T) {
+	assert.Nil(t, NewValidationError(nil, nil))
+
+	err := NewValidationError(
+		ValidateServiceName(""),
+		ValidateServiceDescription(strings.Repeat("a", 2001)),
+	)
+	assert.NotNil(t, err)
+	assert.Equal(t, 422, err.Status)
+	assert.Len(t, err.Fields, 2)
+	assert.Equal(t, "name", err.Fields[0].Field)
+	assert.Equal(t, "description", err.Fields[1].Field)
+}
+
+func TestValidateVersionNumber(t *testing.T) {
+	assert.Nil(t, ValidateVersionNumber("1.2.3"))
+	assert.Nil(t, ValidateVersionNumber("2.0.0-rc.1"))
+
+	err := ValidateVersionNumber("")
+	assert.NotNil(t, err)
+	assert.Equal(t, "is required", err.Message)
+
+	err = ValidateVersionNumber("1.2")
+	assert.NotNil(t, err)
+	assert.Equal(t, "number", err.Field)
+}
+
+func TestValidateVersionRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         string
+		expected      VersionRef
+		expectedError bool
+	}{
+		{name: "Version ID", param: "3", expected: VersionRef{ID: 3}},
+		{name: "Version number", param: "2.0.0-rc.1", expected: VersionRef{Number: "2.0.0-rc.1"}},
+		{name: "Zero ID", param: "0", expectedError: true},
+		{name: "Partial number", param: "2.0", expectedError: true},
+		{name: "Garbage", param: "latest-ish", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, _ := gin.CreateTestContext(nil)
+			c.Params = []gin.Param{{Key: "version", Value: tt.param}}
+
+			ref, err := ValidateVersionRef(c)
 
 			if tt.expectedError {
 				assert.NotNil(t, err)
 			} else {
 				assert.Nil(t, err)
-				assert.Equal(t, tt.expectedID, id)
+				assert.Equal(t, tt.expected, ref)
 			}
 		})
 	}
 }
+
+func TestValidateContactEmail(t *testing.T) {
+	assert.Nil(t, ValidateContactEmail(""))
+	assert.Nil(t, ValidateContactEmail("payments@example.com"))
+
+	for _, email := range []string{"payments", "Payments <payments@example.com>", "payments@"} {
+		err := ValidateContactEmail(email)
+		if assert.NotNil(t, err, email) {
+			assert.Equal(t, "contact_email", err.Field)
+		}
+	}
+}
+
+func TestValidateLinkURL(t *testing.T) {
+	assert.Nil(t, ValidateLinkURL("https://github.com/example/payments"))
+	assert.Nil(t, ValidateLinkURL("http://grafana.internal:3000/d/abc?orgId=1"))
+
+	for _, value := range []string{"", "github.com/example", "ftp://example.com/file", "https://", "javascript:alert(1)",
+		"https://example.com/" + strings.Repeat("a", 2048)} {
+		err := ValidateLinkURL(value)
+		if assert.NotNil(t, err, value) {
+			assert.Equal(t, "url", err.Field)
+		}
+	}
+}
+
+func TestValidateLinkType(t *testing.T) {
+	assert.Nil(t, ValidateLinkType("runbook"))
+
+	for _, linkType := range []string{"", "Runbook", "wiki"} {
+		err := ValidateLinkType(linkType)
+		if assert.NotNil(t, err, linkType) {
+			assert.Equal(t, "type", err.Field)
+		}
+	}
+}
+
+func TestValidateVersionStatus(t *testing.T) {
+	assert.Nil(t, ValidateVersionStatus("end-of-life"))
+	assert.NotNil(t, ValidateVersionStatus(""))
+	assert.NotNil(t, ValidateVersionStatus("stable"))
+
+	assert.Nil(t, ValidatePublishStatus(""))
+	assert.Nil(t, ValidatePublishStatus("draft"))
+	assert.NotNil(t, ValidatePublishStatus("deprecated"))
+}
+
+func TestValidateInitialLifecycle(t *testing.T) {
+	for _, stage := range []string{"", "proposed", "experimental", "production"} {
+		assert.Nil(t, ValidateInitialLifecycle(stage), stage)
+	}
+	for _, stage := range []string{"deprecated", "retired", "live"} {
+		err := ValidateInitialLifecycle(stage)
+		if assert.NotNil(t, err, stage) {
+			assert.Equal(t, "lifecycle", err.Field)
+		}
+	}
+}
+
+func TestValidateEndOfLifeDate(t *testing.T) {
+	deprecation := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	endOfLife := deprecation.AddDate(0, 6, 0)
+
+	assert.Nil(t, ValidateEndOfLifeDate(&deprecation, &endOfLife))
+	assert.Nil(t, ValidateEndOfLifeDate(nil, &endOfLife))
+	assert.Nil(t, ValidateEndOfLifeDate(&deprecation, nil))
+
+	err := ValidateEndOfLifeDate(&endOfLife, &deprecation)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "end_of_life_date", err.Field)
+	}
+}
+
+func TestValidateDependencyKindAndCriticality(t *testing.T) {
+	assert.Nil(t, ValidateDependencyKind("async-queue"))
+	assert.NotNil(t, ValidateDependencyKind(""))
+	assert.NotNil(t, ValidateDependencyKind("grpc"))
+
+	assert.Nil(t, ValidateCriticality(""))
+	assert.Nil(t, ValidateCriticality("high"))
+	err := ValidateCriticality("extreme")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "criticality", err.Field)
+	}
+}